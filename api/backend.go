@@ -77,6 +77,7 @@ func NewBackend(ctx *node.ServiceContext, ethConfig *eth.Config) (*Backend, erro
 	ethereum.BlockChain().SetValidator(NullBlockProcessor{})
 
 	ethereum.BlockChain().SetUmbrella(&EthUmbrella{})
+	RegisterSystemContract()
 
 	ethBackend := &Backend{
 		ethereum:  ethereum,
@@ -150,8 +151,8 @@ func (b *Backend) DeliverTx(tx *ethTypes.Transaction) abciTypes.ResponseDeliverT
 
 // AccumulateRewards accumulates the rewards based on the given strategy
 // #unstable
-func (b *Backend) AccumulateRewards(config *params.ChainConfig, strategy *emtTypes.Strategy) {
-	b.es.AccumulateRewards(config, strategy)
+func (b *Backend) AccumulateRewards(config *params.ChainConfig, strategy *emtTypes.Strategy, validators []emtTypes.RewardedValidator) []emtTypes.AwardInfo {
+	return b.es.AccumulateRewards(config, strategy, validators)
 }
 
 // Commit finalises the current block
@@ -177,6 +178,11 @@ func (b *Backend) UpdateHeaderWithTimeInfo(tmHeader abciTypes.Header, blockHash
 		uint64(tmHeader.GetNumTxs()), blockHash)
 }
 
+// ExecuteScheduledTxs executes the scheduled calls due at the beginning of the block
+func (b *Backend) ExecuteScheduledTxs() {
+	b.es.ExecuteScheduledTxs()
+}
+
 // GasLimit returns the maximum gas per block
 // #unstable
 func (b *Backend) GasLimit() uint64 {
@@ -24,10 +24,12 @@ import (
 	ttypes "github.com/tendermint/tendermint/types"
 
 	"github.com/second-state/devchain/modules/governance"
+	"github.com/second-state/devchain/modules/schedule"
 	"github.com/second-state/devchain/modules/stake"
 	"github.com/second-state/devchain/sdk"
 	"github.com/second-state/devchain/types"
 	"github.com/second-state/devchain/utils"
+	emtTypes "github.com/second-state/devchain/vm/types"
 )
 
 // CmtRPCService offers cmt related RPC methods
@@ -447,6 +449,75 @@ func (s *CmtRPCService) VerifyCandidacy(args VerifyCandidacyArgs) (*ctypes.Resul
 	return s.signAndBroadcastTxCommit(txArgs)
 }
 
+type CancelCandidacyAccountUpdateArgs struct {
+	Nonce                  *hexutil.Uint64 `json:"nonce"`
+	From                   common.Address  `json:"from"`
+	AccountUpdateRequestId int64           `json:"accountUpdateRequestId"`
+}
+
+func (s *CmtRPCService) CancelCandidacyAccountUpdate(args CancelCandidacyAccountUpdateArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := stake.NewTxCancelCandidacyAccountUpdate(args.AccountUpdateRequestId)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type DelegateArgs struct {
+	Nonce            *hexutil.Uint64 `json:"nonce"`
+	From             common.Address  `json:"from"`
+	CandidateAddress common.Address  `json:"candidateAddress"`
+	Amount           hexutil.Big     `json:"amount"`
+}
+
+func (s *CmtRPCService) Delegate(args DelegateArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := stake.NewTxDelegate(args.CandidateAddress, args.Amount.ToInt().String())
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type UnbondArgs struct {
+	Nonce            *hexutil.Uint64 `json:"nonce"`
+	From             common.Address  `json:"from"`
+	CandidateAddress common.Address  `json:"candidateAddress"`
+	Amount           hexutil.Big     `json:"amount"`
+}
+
+func (s *CmtRPCService) Unbond(args UnbondArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := stake.NewTxUnbond(args.CandidateAddress, args.Amount.ToInt().String())
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type UnjailArgs struct {
+	Nonce *hexutil.Uint64 `json:"nonce"`
+	From  common.Address  `json:"from"`
+}
+
+func (s *CmtRPCService) Unjail(args UnjailArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := stake.NewTxUnjail()
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
 type StakeQueryResult struct {
 	Height int64       `json:"height"`
 	Data   interface{} `json:"data"`
@@ -472,6 +543,63 @@ func (s *CmtRPCService) QueryValidator(address common.Address, height uint64) (*
 	return &StakeQueryResult{h, &candidate}, nil
 }
 
+func (s *CmtRPCService) QueryVerifications(address common.Address, height uint64) (*StakeQueryResult, error) {
+	var verifications []*stake.CandidateVerification
+	h, err := s.getParsedFromJson("/validator/verifications", []byte(address.Hex()), &verifications, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, verifications}, nil
+}
+
+func (s *CmtRPCService) QueryDelegator(address common.Address, height uint64) (*StakeQueryResult, error) {
+	var delegations []*stake.Delegation
+	h, err := s.getParsedFromJson("/delegator", []byte(address.Hex()), &delegations, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, delegations}, nil
+}
+
+// QueryAccountUpdateRequests lists the pending account update requests
+// of a candidate, or the ones targeting an address if byTarget is true
+func (s *CmtRPCService) QueryAccountUpdateRequests(address common.Address, byTarget bool, height uint64) (*StakeQueryResult, error) {
+	path := "/account_update_requests/candidate"
+	if byTarget {
+		path = "/account_update_requests/to"
+	}
+
+	var reqs []*stake.CandidateAccountUpdateRequest
+	h, err := s.getParsedFromJson(path, []byte(address.Hex()), &reqs, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, reqs}, nil
+}
+
+func (s *CmtRPCService) QueryUnbondings(address common.Address, height uint64) (*StakeQueryResult, error) {
+	var unbondings []*stake.Unbonding
+	h, err := s.getParsedFromJson("/unbondings", []byte(address.Hex()), &unbondings, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, unbondings}, nil
+}
+
+func (s *CmtRPCService) QueryFeeDistributions(height uint64) (*StakeQueryResult, error) {
+	var fds []*stake.FeeDistribution
+	h, err := s.getParsedFromJson("/fee_distributions", []byte{0}, &fds, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, fds}, nil
+}
+
 type GovernanceTransferFundProposalArgs struct {
 	Nonce             *hexutil.Uint64 `json:"nonce"`
 	From              common.Address  `json:"from"`
@@ -496,6 +624,55 @@ func (s *CmtRPCService) ProposeTransferFund(args GovernanceTransferFundProposalA
 	return s.signAndBroadcastTxCommit(txArgs)
 }
 
+type GovernanceGrantProposalArgs struct {
+	Nonce             *hexutil.Uint64 `json:"nonce"`
+	From              common.Address  `json:"from"`
+	TransferFrom      common.Address  `json:"transferFrom"`
+	TransferTo        common.Address  `json:"transferTo"`
+	Amount            hexutil.Big     `json:"amount"`
+	Tranches          hexutil.Uint64  `json:"tranches"`
+	Interval          int64           `json:"interval"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expireTimestamp"`
+	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+}
+
+// ProposeGrant proposes to pay the amount in tranches, one every interval blocks once approved
+func (s *CmtRPCService) ProposeGrant(args GovernanceGrantProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxGrantPropose(&args.TransferFrom, &args.TransferTo,
+		args.Amount.ToInt().String(), uint64(args.Tranches), args.Interval, args.Reason,
+		args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+type GovernanceRevokeGrantProposalArgs struct {
+	Nonce             *hexutil.Uint64 `json:"nonce"`
+	From              common.Address  `json:"from"`
+	GrantId           string          `json:"grantId"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expireTimestamp"`
+	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+}
+
+// ProposeRevokeGrant proposes to stop paying a grant and refund its unpaid remainder
+func (s *CmtRPCService) ProposeRevokeGrant(args GovernanceRevokeGrantProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxRevokeGrantPropose(args.GrantId, args.Reason,
+		args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
 type GovernanceChangeParamProposalArgs struct {
 	Nonce             *hexutil.Uint64 `json:"nonce"`
 	From              common.Address  `json:"from"`
@@ -518,6 +695,28 @@ func (s *CmtRPCService) ProposeChangeParam(args GovernanceChangeParamProposalArg
 	return s.signAndBroadcastTxCommit(txArgs)
 }
 
+type GovernanceChangeParamsProposalArgs struct {
+	Nonce             *hexutil.Uint64     `json:"nonce"`
+	From              common.Address      `json:"from"`
+	Params            []utils.ParamChange `json:"params"`
+	Reason            string              `json:"reason"`
+	ExpireTimestamp   *int64              `json:"expireTimestamp"`
+	ExpireBlockHeight *int64              `json:"expireBlockHeight"`
+}
+
+// ProposeChangeParams proposes to change several params at once, they are all set or none is
+func (s *CmtRPCService) ProposeChangeParams(args GovernanceChangeParamsProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxChangeParamsPropose(args.Params, args.Reason,
+		args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
 type GovernanceDeployLibEniProposalArgs struct {
 	Nonce             *hexutil.Uint64 `json:"nonce"`
 	From              common.Address  `json:"from"`
@@ -584,6 +783,34 @@ func (s *CmtRPCService) ProposeUpgradeProgram(args GovernanceUpgradeProgramPropo
 	return s.signAndBroadcastTxCommit(txArgs)
 }
 
+type GovernanceCallContractProposalArgs struct {
+	Nonce             *hexutil.Uint64 `json:"nonce"`
+	From              common.Address  `json:"from"`
+	ContractAddress   common.Address  `json:"contractAddress"`
+	Value             *hexutil.Big    `json:"value"`
+	Data              hexutil.Bytes   `json:"data"`
+	GasLimit          hexutil.Uint64  `json:"gasLimit"`
+	Reason            string          `json:"reason"`
+	ExpireTimestamp   *int64          `json:"expireTimestamp"`
+	ExpireBlockHeight *int64          `json:"expireBlockHeight"`
+}
+
+func (s *CmtRPCService) ProposeCallContract(args GovernanceCallContractProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	value := "0"
+	if args.Value != nil {
+		value = args.Value.ToInt().String()
+	}
+	tx := governance.NewTxCallContractPropose(&args.ContractAddress, value, args.Data.String(),
+		uint64(args.GasLimit), args.Reason, args.ExpireTimestamp, args.ExpireBlockHeight)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
 type GovernanceVoteArgs struct {
 	Nonce      *hexutil.Uint64 `json:"nonce"`
 	Voter      common.Address  `json:"from"`
@@ -602,9 +829,36 @@ func (s *CmtRPCService) Vote(args GovernanceVoteArgs) (*ctypes.ResultBroadcastTx
 	return s.signAndBroadcastTxCommit(txArgs)
 }
 
-func (s *CmtRPCService) QueryProposals() (*StakeQueryResult, error) {
+type GovernanceCancelProposalArgs struct {
+	Nonce      *hexutil.Uint64 `json:"nonce"`
+	From       common.Address  `json:"from"`
+	ProposalId string          `json:"proposalId"`
+}
+
+func (s *CmtRPCService) CancelProposal(args GovernanceCancelProposalArgs) (*ctypes.ResultBroadcastTxCommit, error) {
+	tx := governance.NewTxCancelProposal(args.ProposalId)
+
+	txArgs, err := s.makeTravisTxArgs(tx, args.From, args.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAndBroadcastTxCommit(txArgs)
+}
+
+// QueryProposals lists all the proposals, or only the ones matching the optional filter
+func (s *CmtRPCService) QueryProposals(filter *governance.ProposalFilter) (*StakeQueryResult, error) {
+	data := []byte{0}
+	if filter != nil {
+		b, err := json.Marshal(filter)
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+
 	var proposals []*governance.Proposal
-	h, err := s.getParsedFromJson("/governance/proposals", []byte{0}, &proposals, 0)
+	h, err := s.getParsedFromJson("/governance/proposals", data, &proposals, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -612,6 +866,37 @@ func (s *CmtRPCService) QueryProposals() (*StakeQueryResult, error) {
 	return &StakeQueryResult{h, proposals}, nil
 }
 
+func (s *CmtRPCService) QueryProposal(pid string) (*StakeQueryResult, error) {
+	var proposal governance.Proposal
+	h, err := s.getParsedFromJson("/governance/proposal", []byte(pid), &proposal, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, &proposal}, nil
+}
+
+func (s *CmtRPCService) QueryVotes(pid string) (*StakeQueryResult, error) {
+	var votes []*governance.Vote
+	h, err := s.getParsedFromJson("/governance/votes", []byte(pid), &votes, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, votes}, nil
+}
+
+// QueryTally returns the current breakdown of the validators' voting power on the proposal
+func (s *CmtRPCService) QueryTally(pid string) (*StakeQueryResult, error) {
+	var tally governance.TallyResult
+	h, err := s.getParsedFromJson("/governance/tally", []byte(pid), &tally, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, &tally}, nil
+}
+
 func (s *CmtRPCService) QueryParams(height uint64) (*StakeQueryResult, error) {
 	var params utils.Params
 	h, err := s.getParsedFromJson("/key", utils.ParamKey, &params, height)
@@ -620,3 +905,32 @@ func (s *CmtRPCService) QueryParams(height uint64) (*StakeQueryResult, error) {
 	}
 	return &StakeQueryResult{h, params}, nil
 }
+
+// QueryParamChanges lists the changes of the param made by proposals, with the block height
+// they were applied at and the old and new values, all the params' if the name is empty
+func (s *CmtRPCService) QueryParamChanges(name string) (*StakeQueryResult, error) {
+	var logs []*governance.ParamChangeLog
+	h, err := s.getParsedFromJson("/governance/param_changes", governance.ParamChangesQueryData(name), &logs, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeQueryResult{h, logs}, nil
+}
+
+func (s *CmtRPCService) QueryScheduledTxs(txHash common.Hash) (*StakeQueryResult, error) {
+	var txs []*schedule.ScheduledTx
+	h, err := s.getParsedFromJson("/scheduled_txs", txHash.Bytes(), &txs, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeQueryResult{h, txs}, nil
+}
+
+func (s *CmtRPCService) QueryAwardInfos(height uint64) (*StakeQueryResult, error) {
+	var awards []emtTypes.AwardInfo
+	h, err := s.getParsedFromJson("/key", utils.AwardInfosKey, &awards, height)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeQueryResult{h, awards}, nil
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"github.com/second-state/devchain/modules/schedule"
 	"github.com/second-state/devchain/modules/stake"
 	"github.com/second-state/devchain/utils"
 	"github.com/ethereum/go-ethereum/common"
@@ -23,11 +24,38 @@ func (eu *EthUmbrella) GetValidators() []common.Address {
 	return vs
 }
 
+// EmitScheduleTx records the call scheduled by the transaction being delivered,
+// it is executed at the beginning of the first block whose time reaches the due time
 func (eu *EthUmbrella) EmitScheduleTx(stx um.ScheduleTx) {
+	value := big.NewInt(0)
+	if stx.Value != nil {
+		value = stx.Value
+	}
+	schedule.Schedule(&schedule.ScheduledTx{
+		TxHash:       stx.TxHash,
+		From:         stx.From,
+		To:           stx.To,
+		Value:        value.String(),
+		Data:         common.Bytes2Hex(stx.Data),
+		DueTimestamp: int64(stx.Unixtime),
+	})
 }
 
+// GetDueTxs returns the scheduled calls due at the time of the block being delivered
 func (eu *EthUmbrella) GetDueTxs() []um.ScheduleTx {
-	return nil
+	var stxs []um.ScheduleTx
+	for _, s := range schedule.GetDueTxs() {
+		value, _ := new(big.Int).SetString(s.Value, 10)
+		stxs = append(stxs, um.ScheduleTx{
+			Unixtime: uint64(s.DueTimestamp),
+			TxHash:   s.TxHash,
+			From:     s.From,
+			To:       s.To,
+			Value:    value,
+			Data:     common.Hex2Bytes(s.Data),
+		})
+	}
+	return stxs
 }
 
 func (eu *EthUmbrella) DefaultGasPrice() *big.Int {
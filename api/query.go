@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/spf13/cast"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
@@ -47,5 +48,8 @@ func (s *CmtRPCService) get(path string, key []byte, height int64) ([]byte, int6
 	if resp == nil {
 		return nil, height, err
 	}
+	if resp.Response.IsErr() {
+		return nil, resp.Response.Height, errors.New(resp.Response.Log)
+	}
 	return resp.Response.Value, resp.Response.Height, err
 }
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/second-state/devchain/modules/governance"
+	"github.com/second-state/devchain/modules/stake"
+	"github.com/second-state/devchain/types"
+	"github.com/second-state/devchain/utils"
+)
+
+/**
+The system contract gives contracts read access to the stake and governance state, it is
+called at utils.SystemContractAddress through the interface below. It reads the state
+committed by the last block, the same when the block is delivered as when eth_call is
+served, the changes of the block being delivered are seen from the next block.
+
+interface SystemContract {
+	function getValidators() external view returns (address[]);
+	function getCandidate(address owner) external view returns (bool found, string pubKey, uint256 votingPower, uint256 shares, bool verified, bool active, string state, string name, string website);
+	function getActiveProposals() external view returns (string[]);
+	function getProposal(string id) external view returns (bool found, string proposalType, address proposer, uint256 blockHeight, uint256 expireBlockHeight, uint256 expireTimestamp, string result);
+	function getTally(string id) external view returns (bool found, uint256 yes, uint256 no, uint256 noWithVeto, uint256 abstain, uint256 nonVoting, uint256 total);
+	function getParam(string name) external view returns (bool found, string value);
+}
+*/
+
+const systemContractAbi = `[
+	{"name":"getValidators","type":"function","constant":true,"inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+	{"name":"getCandidate","type":"function","constant":true,"inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"found","type":"bool"},{"name":"pubKey","type":"string"},{"name":"votingPower","type":"uint256"},{"name":"shares","type":"uint256"},{"name":"verified","type":"bool"},{"name":"active","type":"bool"},{"name":"state","type":"string"},{"name":"name","type":"string"},{"name":"website","type":"string"}]},
+	{"name":"getActiveProposals","type":"function","constant":true,"inputs":[],"outputs":[{"name":"","type":"string[]"}]},
+	{"name":"getProposal","type":"function","constant":true,"inputs":[{"name":"id","type":"string"}],"outputs":[{"name":"found","type":"bool"},{"name":"proposalType","type":"string"},{"name":"proposer","type":"address"},{"name":"blockHeight","type":"uint256"},{"name":"expireBlockHeight","type":"uint256"},{"name":"expireTimestamp","type":"uint256"},{"name":"result","type":"string"}]},
+	{"name":"getTally","type":"function","constant":true,"inputs":[{"name":"id","type":"string"}],"outputs":[{"name":"found","type":"bool"},{"name":"yes","type":"uint256"},{"name":"no","type":"uint256"},{"name":"noWithVeto","type":"uint256"},{"name":"abstain","type":"uint256"},{"name":"nonVoting","type":"uint256"},{"name":"total","type":"uint256"}]},
+	{"name":"getParam","type":"function","constant":true,"inputs":[{"name":"name","type":"string"}],"outputs":[{"name":"found","type":"bool"},{"name":"value","type":"string"}]}
+]`
+
+const (
+	// gas of a call, and of each 32 bytes word of its result
+	systemContractBaseGas = 2000
+	systemContractWordGas = 100
+)
+
+var errUnknownSystemMethod = errors.New("unknown system contract method")
+
+// SystemContract is the precompiled contract reading the stake and governance state
+type SystemContract struct {
+	abi abi.ABI
+}
+
+func NewSystemContract() *SystemContract {
+	parsed, err := abi.JSON(strings.NewReader(systemContractAbi))
+	if err != nil {
+		panic(err)
+	}
+	return &SystemContract{parsed}
+}
+
+// RegisterSystemContract makes the system contract callable by the contracts
+func RegisterSystemContract() {
+	sc := NewSystemContract()
+	vm.PrecompiledContractsHomestead[utils.SystemContractAddress] = sc
+	vm.PrecompiledContractsByzantium[utils.SystemContractAddress] = sc
+}
+
+// RequiredGas runs the call to price the size of its result,
+// a call which fails is charged the base gas
+func (sc *SystemContract) RequiredGas(input []byte) uint64 {
+	ret, err := sc.Run(input)
+	if err != nil {
+		return systemContractBaseGas
+	}
+	return systemContractBaseGas + uint64((len(ret)+31)/32)*systemContractWordGas
+}
+
+func (sc *SystemContract) Run(input []byte) ([]byte, error) {
+	method, err := sc.method(input)
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.UnpackValues(input[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case "getValidators":
+		return method.Outputs.Pack(sc.getValidators())
+	case "getCandidate":
+		return sc.getCandidate(method, args[0].(common.Address))
+	case "getActiveProposals":
+		return method.Outputs.Pack(sc.getActiveProposals())
+	case "getProposal":
+		return sc.getProposal(method, args[0].(string))
+	case "getTally":
+		return sc.getTally(method, args[0].(string))
+	case "getParam":
+		value, found := utils.GetCommittedParamValue(args[0].(string))
+		return method.Outputs.Pack(found, value)
+	}
+	return nil, errUnknownSystemMethod
+}
+
+func (sc *SystemContract) method(input []byte) (*abi.Method, error) {
+	if len(input) < 4 {
+		return nil, errUnknownSystemMethod
+	}
+	for _, method := range sc.abi.Methods {
+		if string(method.Id()) == string(input[:4]) {
+			m := method
+			return &m, nil
+		}
+	}
+	return nil, errUnknownSystemMethod
+}
+
+func (sc *SystemContract) getValidators() []common.Address {
+	vs := []common.Address{}
+	for _, v := range stake.QueryCandidates().Validators() {
+		vs = append(vs, common.HexToAddress(v.OwnerAddress))
+	}
+	return vs
+}
+
+func (sc *SystemContract) getCandidate(method *abi.Method, owner common.Address) ([]byte, error) {
+	candidate := stake.QueryCandidateByAddress(owner)
+	if candidate == nil {
+		return method.Outputs.Pack(false, "", big.NewInt(0), big.NewInt(0), false, false, "", "", "")
+	}
+
+	return method.Outputs.Pack(true, types.PubKeyString(candidate.PubKey), big.NewInt(candidate.VotingPower), candidate.ParseShares().Int,
+		candidate.Verified == "Y", candidate.IsActive(), candidate.State, candidate.Description.Name, candidate.Description.Website)
+}
+
+func (sc *SystemContract) getActiveProposals() []string {
+	ids := []string{}
+	for _, p := range governance.QueryProposalsByFilter(&governance.ProposalFilter{Status: "pending"}) {
+		ids = append(ids, p.Id)
+	}
+	return ids
+}
+
+func (sc *SystemContract) getProposal(method *abi.Method, pid string) ([]byte, error) {
+	proposal := governance.QueryProposal(pid)
+	if proposal == nil {
+		return method.Outputs.Pack(false, "", common.Address{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), "")
+	}
+
+	proposer := common.Address{}
+	if proposal.Proposer != nil {
+		proposer = *proposal.Proposer
+	}
+	return method.Outputs.Pack(true, proposal.Type, proposer, big.NewInt(proposal.BlockHeight),
+		big.NewInt(proposal.ExpireBlockHeight), big.NewInt(proposal.ExpireTimestamp), proposal.Result)
+}
+
+func (sc *SystemContract) getTally(method *abi.Method, pid string) ([]byte, error) {
+	tally := governance.QueryTally(pid)
+	if tally == nil {
+		return method.Outputs.Pack(false, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0))
+	}
+	return method.Outputs.Pack(true, big.NewInt(tally.Yes), big.NewInt(tally.No), big.NewInt(tally.NoWithVeto),
+		big.NewInt(tally.Abstain), big.NewInt(tally.NonVoting), big.NewInt(tally.Total))
+}
@@ -3,11 +3,13 @@ package app
 import (
 	"bytes"
 	"database/sql"
+	"encoding/json"
 	goerr "errors"
 	"math/big"
 	"strings"
 
 	"github.com/second-state/devchain/modules/governance"
+	"github.com/second-state/devchain/modules/schedule"
 	"github.com/second-state/devchain/modules/stake"
 	"github.com/second-state/devchain/sdk"
 	"github.com/second-state/devchain/sdk/dbm"
@@ -17,13 +19,13 @@ import (
 	ttypes "github.com/second-state/devchain/types"
 	"github.com/second-state/devchain/utils"
 	"github.com/second-state/devchain/version"
+	emtTypes "github.com/second-state/devchain/vm/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth"
 	abci "github.com/tendermint/tendermint/abci/types"
 
-	"github.com/tendermint/tendermint/crypto/ed25519"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -46,6 +48,10 @@ var (
 // NewBaseApp extends a StoreApp with a handler and a ticker,
 // which it binds to the proper abci calls
 func NewBaseApp(store *StoreApp, ethApp *EthermintApplication, ethereum *eth.Ethereum) (*BaseApp, error) {
+	if err := migrateDb(); err != nil {
+		return nil, err
+	}
+
 	// init pending proposals
 	pendingProposals := governance.GetPendingProposals()
 	if len(pendingProposals) > 0 {
@@ -73,6 +79,14 @@ func NewBaseApp(store *StoreApp, ethApp *EthermintApplication, ethereum *eth.Eth
 	if b != nil {
 		utils.LoadParams(b)
 	}
+	store.loadCommittedParams()
+
+	if err := initCommitMarker(); err != nil {
+		return nil, err
+	}
+	if err := initDbHash(); err != nil {
+		return nil, err
+	}
 
 	app := &BaseApp{
 		StoreApp:  store,
@@ -80,6 +94,10 @@ func NewBaseApp(store *StoreApp, ethApp *EthermintApplication, ethereum *eth.Eth
 		checkedTx: make(map[common.Hash]*types.Transaction),
 		ethereum:  ethereum,
 	}
+
+	// recover from a commit interrupted before the database was committed
+	app.checkCommittedHeights()
+
 	return app, nil
 }
 
@@ -126,7 +144,7 @@ func (app *BaseApp) Info(req abci.RequestInfo) abci.ResponseInfo {
 	if governance.GetLatestRetiredHeight() == lbh {
 		travisDbHash = app.StoreApp.GetOldDbHash()
 	} else {
-		travisDbHash = app.StoreApp.GetDbHash()
+		travisDbHash = app.StoreApp.GetDbHash(lbh)
 	}
 
 	travisInfoRes.LastBlockAppHash = finalAppHash(ethInfoRes.LastBlockAppHash, travisInfoRes.LastBlockAppHash, travisDbHash, travisInfoRes.LastBlockHeight, nil)
@@ -206,18 +224,42 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	app.deliverSqlTx = deliverSqlTx
 	stake.SetDeliverSqlTx(deliverSqlTx)
 	governance.SetDeliverSqlTx(deliverSqlTx)
+	schedule.SetDeliverSqlTx(deliverSqlTx)
 	// init end
 
+	// execute the scheduled calls due at this block first
+	schedule.SetBlock(req.Header.Height, app.blockTime)
+	app.EthApp.ExecuteScheduledTxs()
+
 	app.proposer = req.Header.Proposer
 
+	// punish the validators which were absent from the last commit or double-signed
+	stake.RecordAbsentValidators(app.Append(), req.Header.Height-1, req.LastCommitInfo.Validators)
+	stake.SlashByzantineValidators(req.Header.Height, req.ByzantineValidators)
+
 	return abci.ResponseBeginBlock{}
 }
 
 // EndBlock - ABCI - triggers Tick actions
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
-	app.EthApp.EndBlock(req)
+	app.EthApp.EndBlock(req, rewardedValidators())
+
+	// keep the block rewards of this height
+	if b, err := json.Marshal(app.EthApp.AwardInfos()); err == nil {
+		app.Append().Set(utils.AwardInfosKey, b)
+	}
+
 	utils.BlockGasFee = big.NewInt(0).Add(utils.BlockGasFee, app.TotalUsedGasFee)
 
+	// release the coins whose unbonding period ends in this block and
+	// expire the stale account update requests
+	stake.ReleaseUnbondings(app.WorkingHeight())
+	stake.ExpireCandidateAccountUpdateRequests(app.WorkingHeight())
+
+	// pay out the gas fees collected in this block
+	stake.DistributeBlockFee(app.WorkingHeight(), app.proposer.Address, sdk.NewIntFromBigInt(utils.BlockGasFee))
+	utils.BlockGasFee = big.NewInt(0)
+
 	// Deactivate validators that not in the list of preserved validators
 	if utils.RetiringProposalId != "" {
 		if proposal := governance.GetProposalById(utils.RetiringProposalId); proposal != nil {
@@ -238,8 +280,7 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 				}
 				if i == len(pks) {
 					inaVs = append(inaVs, v)
-					pk := v.PubKey.PubKey.(ed25519.PubKeyEd25519)
-					abciVs = append(abciVs, abci.Ed25519Validator(pk[:], 0))
+					abciVs = append(abciVs, abci.Validator{PubKey: v.PubKey.ABCIPubKey(), Power: 0})
 				}
 			}
 			if pvSize >= 1 {
@@ -264,9 +305,32 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 		app.AddValChange(diff)
 	}
 
+	// keep the candidates of this height for historical queries
+	stake.SaveCandidatesSnapshot(app.Append())
+
 	return app.StoreApp.EndBlock(req)
 }
 
+// rewardedValidators lists the active validators and their delegations for the miner reward strategy
+func rewardedValidators() []emtTypes.RewardedValidator {
+	var validators []emtTypes.RewardedValidator
+	for _, v := range stake.GetCandidates().Validators() {
+		rv := emtTypes.RewardedValidator{
+			Owner:       common.HexToAddress(v.OwnerAddress),
+			VotingPower: v.VotingPower,
+			Shares:      utils.ParseInt(v.Shares).Int,
+		}
+		for _, d := range stake.GetDelegationsByCandidate(v.Id) {
+			rv.Delegations = append(rv.Delegations, emtTypes.Delegation{
+				Delegator: d.DelegatorAddress,
+				Shares:    d.ParseShares().Int,
+			})
+		}
+		validators = append(validators, rv)
+	}
+	return validators
+}
+
 func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	if toBeShutdown {
 		server.StopFlag <- true
@@ -275,24 +339,30 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	app.checkedTx = make(map[common.Hash]*types.Transaction)
 	ethAppCommit, err := app.EthApp.Commit()
 	if err != nil {
-		// Rollback transaction
+		// Rollback transaction, and die before the merkle store is committed
+		// so that all the stores stay at the last height and the block is replayed
 		if app.deliverSqlTx != nil {
-			err := app.deliverSqlTx.Rollback()
-			if err != nil {
+			if err := app.deliverSqlTx.Rollback(); err != nil {
 				panic(err)
 			}
 			stake.ResetDeliverSqlTx()
 			governance.ResetDeliverSqlTx()
+			schedule.ResetDeliverSqlTx()
 		}
+		panic(err)
 	} else {
-		if app.deliverSqlTx != nil {
-			// Commit transaction
-			err := app.deliverSqlTx.Commit()
-			if err != nil {
-				panic(err)
-			}
-			stake.ResetDeliverSqlTx()
-			governance.ResetDeliverSqlTx()
+		// keep the stake and governance records in the merkle store, the records of
+		// the database are copied once when the store holds none of this version yet
+		store := app.Append()
+		if !bytes.Equal(store.Get(utils.StoreMigratedKey), utils.StoreVersion) {
+			stake.MigrateStore(store)
+			governance.MigrateStore(store)
+			stake.ResetStoreChanges()
+			governance.ResetStoreChanges()
+			store.Set(utils.StoreMigratedKey, utils.StoreVersion)
+		} else {
+			stake.SyncStore(store)
+			governance.SyncStore(store)
 		}
 	}
 
@@ -302,12 +372,26 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 		state := app.Append()
 		state.Set(utils.ParamKey, utils.UnloadParams())
 	}
+	app.recordDbHashUpgrade(workingHeight)
 
 	// reset store app
 	app.TotalUsedGasFee = big.NewInt(0)
 
 	res = app.StoreApp.Commit()
-	dbHash := app.StoreApp.GetDbHash()
+	app.loadCommittedParams()
+
+	// the database is committed last, along with the height of the block
+	if app.deliverSqlTx != nil {
+		setCommitMarker(app.deliverSqlTx, workingHeight)
+		if err := app.deliverSqlTx.Commit(); err != nil {
+			panic(err)
+		}
+		stake.ResetDeliverSqlTx()
+		governance.ResetDeliverSqlTx()
+		schedule.ResetDeliverSqlTx()
+	}
+	app.StoreApp.UpdateDbHash()
+	dbHash := app.StoreApp.GetDbHash(workingHeight)
 	res.Data = finalAppHash(ethAppCommit.Data, res.Data, dbHash, workingHeight, nil)
 
 	return
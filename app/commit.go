@@ -0,0 +1,92 @@
+package app
+
+import (
+	"database/sql"
+
+	ethUtils "github.com/ethereum/go-ethereum/cmd/utils"
+
+	"github.com/second-state/devchain/sdk/dbm"
+	"github.com/second-state/devchain/utils"
+)
+
+// A block is committed to the ethereum state first, then to the merkle store, and last
+// to the database whose transaction records the height of the block in commit_marker.
+// The database is the only store which can not be rolled back, so on startup the stores
+// left ahead of it by an interrupted commit are rolled back to its height, and the
+// following blocks are replayed.
+
+func initCommitMarker() error {
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("create table if not exists commit_marker(id integer not null primary key, height integer not null)")
+	return err
+}
+
+// setCommitMarker records the height in the transaction committing the block to the database
+func setCommitMarker(tx *sql.Tx, height int64) {
+	if _, err := tx.Exec("insert or replace into commit_marker(id, height) values(1, ?)", height); err != nil {
+		panic(err)
+	}
+}
+
+// getCommitMarker returns the height of the last block committed to the database,
+// none before the first commit recording it
+func getCommitMarker() (int64, bool) {
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		panic(err)
+	}
+
+	var height int64
+	err = db.QueryRow("select height from commit_marker where id = 1").Scan(&height)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, false
+	case err != nil:
+		panic(err)
+	}
+	return height, true
+}
+
+// checkCommittedHeights compares the heights of the stores with the one of the database.
+// The ethereum state and the merkle store ahead of it are rolled back, a store behind it
+// can not be recovered and the node refuses to start.
+func (app *BaseApp) checkCommittedHeights() {
+	dbHeight, ok := getCommitMarker()
+	if !ok {
+		return
+	}
+
+	ethHeight := app.EthApp.backend.Ethereum().BlockChain().CurrentBlock().Number().Int64()
+	storeHeight := app.StoreApp.CommittedHeight()
+	if ethHeight == dbHeight && storeHeight == dbHeight {
+		return
+	}
+
+	if ethHeight < dbHeight || storeHeight < dbHeight {
+		ethUtils.Fatalf("Inconsistent committed heights: ethereum state %d, merkle store %d, database %d. "+
+			"The stores behind the database can not be recovered, please restore the data directory from a backup or resync the node.",
+			ethHeight, storeHeight, dbHeight)
+	}
+
+	app.logger.Info("Rolling back an interrupted commit",
+		"ethereum", ethHeight, "store", storeHeight, "database", dbHeight)
+
+	if ethHeight > dbHeight {
+		if err := app.EthApp.Rollback(dbHeight); err != nil {
+			ethUtils.Fatalf("Rolling back the ethereum state to height %d: %v", dbHeight, err)
+		}
+	}
+	if storeHeight > dbHeight {
+		if err := app.StoreApp.Rollback(dbHeight); err != nil {
+			ethUtils.Fatalf("Rolling back the merkle store to height %d: %v", dbHeight, err)
+		}
+		if b := app.Append().Get(utils.ParamKey); b != nil {
+			utils.LoadParams(b)
+		}
+		app.loadCommittedParams()
+	}
+}
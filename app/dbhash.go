@@ -0,0 +1,239 @@
+package app
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/second-state/devchain/sdk/dbm"
+)
+
+// The database hash is folded from a running sum of the row hashes of each hashed
+// table, so that a commit only processes the rows changed in the block instead of
+// rescanning the tables. Triggers log the hashes of the inserted, updated and deleted
+// rows in db_hash_changes, which are added to or subtracted from the accumulators.
+
+// dbHashTables are the tables whose rows are part of the app hash
+var dbHashTables = []string{"candidates", "governance_proposal", "governance_vote", "candidate_account_update_requests", "delegations", "unbondings", "fee_distributions", "candidate_verifications", "governance_proposal_deposit", "governance_param_change_log", "scheduled_txs"}
+
+// the unique keys of the tables replacing the conflicting rows, a replaced row is
+// deleted without firing the delete trigger
+var dbHashReplaceKeys = map[string][]string{
+	"candidate_verifications": {"candidate_id", "verifier"},
+	"governance_vote":         {"proposal_id", "voter"},
+}
+
+var dbHashModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// initDbHash creates the accumulators and the triggers feeding them if missing, the
+// accumulator of a table already holding rows is computed once from all its rows.
+// The changes left over by an interrupted commit are applied.
+func initDbHash() error {
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmts := []string{
+		"create table if not exists db_hash_accumulators(table_name text not null primary key, acc text not null)",
+		"create table if not exists db_hash_changes(id integer primary key autoincrement, table_name text not null, old_hash text, new_hash text)",
+	}
+	for _, table := range dbHashTables {
+		stmts = append(stmts,
+			fmt.Sprintf("create trigger if not exists %s_hash_insert after insert on %s begin insert into db_hash_changes(table_name, old_hash, new_hash) values('%s', null, new.hash); end", table, table, table),
+			fmt.Sprintf("create trigger if not exists %s_hash_update after update of hash on %s begin insert into db_hash_changes(table_name, old_hash, new_hash) values('%s', old.hash, new.hash); end", table, table, table),
+			fmt.Sprintf("create trigger if not exists %s_hash_delete after delete on %s begin insert into db_hash_changes(table_name, old_hash, new_hash) values('%s', old.hash, null); end", table, table, table),
+		)
+		if keys, ok := dbHashReplaceKeys[table]; ok {
+			stmts = append(stmts, fmt.Sprintf("create trigger if not exists %s_hash_replace before insert on %s begin insert into db_hash_changes(table_name, old_hash, new_hash) select '%s', hash, null from %s where %s = new.%s and %s = new.%s; end", table, table, table, table, keys[0], keys[0], keys[1], keys[1]))
+		}
+	}
+	for _, stmt := range stmts {
+		if _, err = tx.Exec(stmt); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	accs, err := loadDbHashAccumulators(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, table := range dbHashTables {
+		if _, ok := accs[table]; ok {
+			continue
+		}
+		if err = initDbHashAccumulator(tx, table); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err = applyDbHashChanges(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// computes the accumulator of the table from all its rows, the logged changes
+// are already part of them
+func initDbHashAccumulator(tx *sql.Tx, table string) error {
+	rows, err := tx.Query("select hash from " + table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	acc := big.NewInt(0)
+	for rows.Next() {
+		var hash string
+		if err = rows.Scan(&hash); err != nil {
+			return err
+		}
+		acc.Add(acc, rowHashValue(hash))
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	acc.Mod(acc, dbHashModulus)
+
+	if _, err = tx.Exec("delete from db_hash_changes where table_name = ?", table); err != nil {
+		return err
+	}
+	_, err = tx.Exec("insert into db_hash_accumulators(table_name, acc) values(?, ?)", table, common.Bytes2Hex(common.LeftPadBytes(acc.Bytes(), 32)))
+	return err
+}
+
+// applyDbHashChanges adds the hashes of the logged new rows to the accumulators and
+// subtracts the hashes of the replaced or deleted ones, then clears the log
+func applyDbHashChanges(tx *sql.Tx) error {
+	rows, err := tx.Query("select table_name, old_hash, new_hash from db_hash_changes order by id")
+	if err != nil {
+		return err
+	}
+
+	deltas := make(map[string]*big.Int)
+	for rows.Next() {
+		var table string
+		var oldHash, newHash sql.NullString
+		if err = rows.Scan(&table, &oldHash, &newHash); err != nil {
+			rows.Close()
+			return err
+		}
+		delta, ok := deltas[table]
+		if !ok {
+			delta = big.NewInt(0)
+			deltas[table] = delta
+		}
+		if oldHash.Valid {
+			delta.Sub(delta, rowHashValue(oldHash.String))
+		}
+		if newHash.Valid {
+			delta.Add(delta, rowHashValue(newHash.String))
+		}
+	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	accs, err := loadDbHashAccumulators(tx)
+	if err != nil {
+		return err
+	}
+	for table, delta := range deltas {
+		acc, ok := accs[table]
+		if !ok {
+			acc = big.NewInt(0)
+		}
+		acc.Add(acc, delta).Mod(acc, dbHashModulus)
+		if _, err = tx.Exec("insert or replace into db_hash_accumulators(table_name, acc) values(?, ?)", table, common.Bytes2Hex(common.LeftPadBytes(acc.Bytes(), 32))); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("delete from db_hash_changes")
+	return err
+}
+
+func loadDbHashAccumulators(tx *sql.Tx) (map[string]*big.Int, error) {
+	rows, err := tx.Query("select table_name, acc from db_hash_accumulators")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accs := make(map[string]*big.Int)
+	for rows.Next() {
+		var table, acc string
+		if err = rows.Scan(&table, &acc); err != nil {
+			return nil, err
+		}
+		accs[table] = new(big.Int).SetBytes(common.Hex2Bytes(acc))
+	}
+	return accs, rows.Err()
+}
+
+func rowHashValue(hash string) *big.Int {
+	h := sha256.Sum256([]byte(hash))
+	return new(big.Int).SetBytes(h[:])
+}
+
+// UpdateDbHash applies the changes of the committed block to the accumulators
+func (app *StoreApp) UpdateDbHash() {
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		panic(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		panic(err)
+	}
+	if err = applyDbHashChanges(tx); err != nil {
+		tx.Rollback()
+		panic(err)
+	}
+	if err = tx.Commit(); err != nil {
+		panic(err)
+	}
+}
+
+// getIncrementalDbHash folds the accumulators of the hashed tables
+func getIncrementalDbHash(db *sql.DB) []byte {
+	tx, err := db.Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	accs, err := loadDbHashAccumulators(tx)
+	if err != nil {
+		panic(err)
+	}
+
+	var hashes []byte
+	for _, table := range dbHashTables {
+		acc, ok := accs[table]
+		if !ok {
+			acc = big.NewInt(0)
+		}
+		hashes = append(hashes, common.LeftPadBytes(acc.Bytes(), 32)...)
+	}
+	return hashing(hashes)
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/second-state/devchain/sdk/dbm"
+	"github.com/second-state/devchain/utils"
+)
+
+// initTestDb points the app at a fresh sqlite database holding the hashed tables
+// with their accumulators, the returned function removes it again
+func initTestDb(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dbm.InitSqliter(filepath.Join(dir, "app.db")); err != nil {
+		t.Fatal(err)
+	}
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range dbHashTables {
+		columns := "hash text not null default ''"
+		if keys, ok := dbHashReplaceKeys[table]; ok {
+			columns = fmt.Sprintf("%s text not null, %s text not null, %s, unique(%s, %s) on conflict replace", keys[0], keys[1], columns, keys[0], keys[1])
+		}
+		if _, err := db.Exec(fmt.Sprintf("create table %s(%s)", table, columns)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := initDbHash(); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		dbm.Sqliter.CloseDB()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestUpgradeIncrementalDbHash(t *testing.T) {
+	assert := assert.New(t)
+	defer initTestDb(t)()
+
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewStoreApp("test", "", 0, log.NewNopLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// commits a block adding a candidate row, as BaseApp.Commit does
+	commit := func(hash string) (height int64, dbHash []byte) {
+		if _, err := db.Exec("insert into candidates(hash) values(?)", hash); err != nil {
+			t.Fatal(err)
+		}
+		height = store.WorkingHeight()
+		store.recordDbHashUpgrade(height)
+		store.Commit()
+		store.UpdateDbHash()
+		return height, store.GetDbHash(height)
+	}
+
+	const upgradeHeight = 3
+	assert.Nil(store.UpgradeIncrementalDbHash(upgradeHeight))
+
+	// the blocks before the upgrade height hash all the rows
+	for _, hash := range []string{"a", "b"} {
+		height, dbHash := commit(hash)
+		assert.True(height < upgradeHeight)
+		assert.Equal(getFullDbHash(db), dbHash)
+	}
+	assert.Nil(store.state.Committed().Get(utils.DbHashHeightKey))
+
+	// the upgrade height is recorded by its block, from which the accumulators are used
+	height, dbHash := commit("c")
+	assert.Equal(int64(upgradeHeight), height)
+	assert.Equal(getIncrementalDbHash(db), dbHash)
+	assert.NotEqual(getFullDbHash(db), dbHash)
+
+	b := store.state.Committed().Get(utils.DbHashHeightKey)
+	if assert.Len(b, 8) {
+		assert.Equal(uint64(upgradeHeight), binary.BigEndian.Uint64(b))
+	}
+
+	_, dbHash = commit("d")
+	assert.Equal(getIncrementalDbHash(db), dbHash)
+
+	// the heights before the switch keep the hash of all the rows
+	assert.Equal(getFullDbHash(db), store.GetDbHash(upgradeHeight-1))
+
+	// the upgrade can be given again on restart, but not moved once recorded
+	assert.Nil(store.UpgradeIncrementalDbHash(upgradeHeight))
+	assert.NotNil(store.UpgradeIncrementalDbHash(upgradeHeight + 10))
+}
+
+func TestUpgradeIncrementalDbHashCommittedHeight(t *testing.T) {
+	assert := assert.New(t)
+	defer initTestDb(t)()
+
+	store, err := NewStoreApp("test", "", 0, log.NewNopLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Commit()
+	store.Commit()
+
+	// the committed blocks can not change their hash
+	assert.NotNil(store.UpgradeIncrementalDbHash(store.CommittedHeight()))
+	assert.Nil(store.UpgradeIncrementalDbHash(store.WorkingHeight()))
+}
@@ -40,6 +40,9 @@ type EthermintApplication struct {
 	// strategy for validator compensation
 	strategy *emtTypes.Strategy
 
+	// rewards awarded in the current block
+	awardInfos []emtTypes.AwardInfo
+
 	logger tmLog.Logger
 
 	lowPriceCheckTransactions   map[FromTo]struct{}
@@ -112,6 +115,22 @@ func (app *EthermintApplication) Info(req abciTypes.RequestInfo) abciTypes.Respo
 	}
 }
 
+// Rollback rewinds the ethereum state to the block of the height, discarding the
+// blocks committed after it
+func (app *EthermintApplication) Rollback(height int64) error {
+	if err := app.backend.Ethereum().BlockChain().SetHead(uint64(height)); err != nil {
+		return err
+	}
+
+	state, err := app.backend.ResetState()
+	if err != nil {
+		return err
+	}
+	app.checkTxState = state
+
+	return app.backend.InitEthState(app.Receiver())
+}
+
 // SetOption sets a configuration option
 // #stable - 0.4.0
 func (app *EthermintApplication) SetOption(req abciTypes.RequestSetOption) abciTypes.ResponseSetOption {
@@ -184,12 +203,18 @@ func (app *EthermintApplication) BeginBlock(beginBlock abciTypes.RequestBeginBlo
 	return abciTypes.ResponseBeginBlock{}
 }
 
+// ExecuteScheduledTxs executes the contract calls scheduled by earlier transactions
+// which are due at the beginning of the block
+func (app *EthermintApplication) ExecuteScheduledTxs() {
+	app.backend.ExecuteScheduledTxs()
+}
+
 // EndBlock accumulates rewards for the validators and updates them
 // #stable - 0.4.0
-func (app *EthermintApplication) EndBlock(endBlock abciTypes.RequestEndBlock) abciTypes.ResponseEndBlock {
+func (app *EthermintApplication) EndBlock(endBlock abciTypes.RequestEndBlock, validators []emtTypes.RewardedValidator) abciTypes.ResponseEndBlock {
 
 	app.logger.Debug("EndBlock", "height", endBlock.GetHeight()) // nolint: errcheck
-	app.backend.AccumulateRewards(app.backend.Ethereum().BlockChain().Config(), app.strategy)
+	app.awardInfos = app.backend.AccumulateRewards(app.backend.Ethereum().BlockChain().Config(), app.strategy, validators)
 
 	app.backend.EndBlock()
 
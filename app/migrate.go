@@ -0,0 +1,93 @@
+package app
+
+import (
+	"database/sql"
+
+	"github.com/second-state/devchain/sdk/dbm"
+)
+
+// A database created by an older release lacks the tables and columns added since,
+// they are created on startup. The definitions match the ones of initDevChainDb.
+
+// migrationColumns are the columns added to the tables of the first release
+var migrationColumns = []struct {
+	table, column, definition string
+}{
+	{"candidates", "shares", "text not null default '0'"},
+	{"candidates", "jailed_until", "integer not null default 0"},
+	{"candidates", "cooldown_until", "integer not null default 0"},
+}
+
+// migrationTables are the tables and indexes added since the first release
+var migrationTables = []string{
+	"create table if not exists delegations(id integer primary key autoincrement, delegator_address text not null, candidate_id integer not null, shares text not null default '0', block_height integer not null, hash text not null default '', unique(delegator_address, candidate_id))",
+	"create index if not exists idx_delegations_candidate_id on delegations(candidate_id)",
+	"create index if not exists idx_delegations_hash on delegations(hash)",
+	"create table if not exists candidate_verifications(candidate_id integer not null, verifier text not null, verified text not null, block_height integer not null, hash text not null default '', unique(candidate_id, verifier) on conflict replace)",
+	"create index if not exists idx_candidate_verifications_hash on candidate_verifications(hash)",
+	"create table if not exists unbondings(id integer primary key autoincrement, candidate_id integer not null, delegator_address text not null, amount text not null, block_height integer not null, release_block_height integer not null, hash text not null default '')",
+	"create index if not exists idx_unbondings_delegator_address on unbondings(delegator_address)",
+	"create index if not exists idx_unbondings_release_block_height on unbondings(release_block_height)",
+	"create index if not exists idx_unbondings_hash on unbondings(hash)",
+	"create table if not exists fee_distributions(id integer primary key autoincrement, block_height integer not null, address text not null, amount text not null, type text not null, hash text not null default '')",
+	"create index if not exists idx_fee_distributions_block_height on fee_distributions(block_height)",
+	"create index if not exists idx_fee_distributions_hash on fee_distributions(hash)",
+	"create table if not exists governance_call_contract_detail(proposal_id text not null, contract_address text not null, value text not null, calldata text not null, gas_limit integer not null, reason text not null, status text not null, gas_used integer not null default 0, return_data text not null default '', tx_hash text not null default '', logs text not null default '')",
+	"create index if not exists idx_governance_call_contract_detail_proposal_id on governance_call_contract_detail(proposal_id)",
+	"create table if not exists governance_change_params_detail(proposal_id text not null, params text not null, reason text not null)",
+	"create index if not exists idx_governance_change_params_detail_proposal_id on governance_change_params_detail(proposal_id)",
+	"create table if not exists governance_param_change_log(proposal_id text not null, name text not null, old_value text not null, new_value text not null, block_height integer not null, hash text not null default '')",
+	"create index if not exists idx_governance_param_change_log_name on governance_param_change_log(name)",
+	"create table if not exists governance_grant_detail(proposal_id text not null, from_address text not null, to_address text not null, amount text not null, tranches integer not null, interval integer not null, reason text not null, status text not null, paid_amount text not null, paid_tranches integer not null default 0, next_block_height integer not null default 0)",
+	"create index if not exists idx_governance_grant_detail_proposal_id on governance_grant_detail(proposal_id)",
+	"create index if not exists idx_governance_grant_detail_status on governance_grant_detail(status, next_block_height)",
+	"create table if not exists governance_revoke_grant_detail(proposal_id text not null, grant_id text not null, reason text not null)",
+	"create index if not exists idx_governance_revoke_grant_detail_proposal_id on governance_revoke_grant_detail(proposal_id)",
+	"create table if not exists scheduled_txs(id integer primary key autoincrement, tx_hash text not null, from_address text not null, to_address text not null, value text not null, data text not null, due_timestamp integer not null, block_height integer not null, status text not null, gas_used integer not null default 0, return_data text not null default '', result_msg text not null default '', executed_block_height integer not null default 0, call_hash text not null default '', logs text not null default '', hash text not null default '')",
+	"create index if not exists idx_scheduled_txs_due on scheduled_txs(status, due_timestamp, block_height)",
+	"create index if not exists idx_scheduled_txs_tx_hash on scheduled_txs(tx_hash)",
+	"create table if not exists governance_proposal_deposit(proposal_id text not null primary key, depositor text not null, amount text not null, status text not null, block_height integer not null, hash text not null default '')",
+	"create index if not exists idx_governance_proposal_deposit_hash on governance_proposal_deposit(hash)",
+}
+
+// migrateDb adds the missing columns and tables to the database
+func migrateDb() error {
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range migrationColumns {
+		exists, err := hasColumn(tx, c.table, c.column)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err = tx.Exec("alter table " + c.table + " add column " + c.column + " " + c.definition); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, stmt := range migrationTables {
+		if _, err = tx.Exec(stmt); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func hasColumn(tx *sql.Tx, table, column string) (bool, error) {
+	var count int
+	err := tx.QueryRow("select count(*) from pragma_table_info(?) where name = ?", table, column).Scan(&count)
+	return count > 0, err
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"bytes"
 	"database/sql"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"github.com/second-state/devchain/modules/stake"
@@ -22,9 +23,11 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/second-state/devchain/modules/governance"
+	"github.com/second-state/devchain/modules/schedule"
 	"github.com/second-state/devchain/sdk/dbm"
 	"github.com/second-state/devchain/sdk/errors"
 	sm "github.com/second-state/devchain/sdk/state"
+	"github.com/second-state/devchain/utils"
 	"github.com/tendermint/go-amino"
 )
 
@@ -54,6 +57,12 @@ type StoreApp struct {
 
 	TotalUsedGasFee *big.Int
 
+	// the height from which the database hash is incremental, 0 until it is loaded
+	dbHashHeight int64
+	// the height an existing chain switches to the incremental database hash at,
+	// it is recorded in the store by the commit of that block
+	dbHashUpgradeHeight int64
+
 	logger log.Logger
 }
 
@@ -122,6 +131,24 @@ func (app *StoreApp) WorkingHeight() int64 {
 	return app.height + 1
 }
 
+// loadCommittedParams keeps a copy of the params of the last commit for the
+// readers of the committed state
+func (app *StoreApp) loadCommittedParams() {
+	if b := app.state.Committed().Get(utils.ParamKey); b != nil {
+		utils.SetCommittedParams(b)
+	}
+}
+
+// Rollback rewinds the store to the block of the height, discarding the blocks
+// committed after it
+func (app *StoreApp) Rollback(height int64) error {
+	if err := app.state.Rollback(height); err != nil {
+		return err
+	}
+	app.height = height
+	return nil
+}
+
 // Info implements abci.Application. It returns the height and hash,
 // as well as the abci name and version.
 //
@@ -187,20 +214,173 @@ func (app *StoreApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQu
 			resQuery.Value = value
 		}
 	case "/validators":
-		candidates := stake.QueryCandidates()
-		b, _ := json.Marshal(candidates)
-		resQuery.Value = b
+		if reqQuery.Height != 0 {
+			// the candidates as they were at the end of the requested block
+			var value []byte
+			if height <= app.CommittedHeight() {
+				_, value = tree.GetVersioned(utils.CandidatesKey, height)
+			}
+			if value == nil {
+				resQuery.Code = errors.CodeTypeBaseInvalidInput
+				resQuery.Log = cmn.Fmt("No candidates snapshot at height %d", height)
+				break
+			}
+			resQuery.Value = value
+		} else {
+			candidates := stake.QueryCandidates()
+			b, _ := json.Marshal(candidates)
+			resQuery.Value = b
+		}
 	case "/validator":
+		// the records at a height or with a proof are read from the store,
+		// the last committed ones from the database
 		address := common.HexToAddress(string(reqQuery.Data))
+		if reqQuery.Prove || reqQuery.Height != 0 {
+			if id, found := candidateIdAt(tree, address, height); found {
+				queryRecord(tree, stake.CandidateKey(id), height, reqQuery.Prove, &resQuery)
+			} else {
+				resQuery.Value = []byte{}
+			}
+			break
+		}
+		resQuery.Height = app.CommittedHeight()
 		candidate := stake.QueryCandidateByAddress(address)
-		if candidate != nil {
+		if candidate == nil {
+			resQuery.Value = []byte{}
+		} else {
 			b, _ := json.Marshal(candidate)
 			resQuery.Value = b
+		}
+	case "/validator/verifications":
+		address := common.HexToAddress(string(reqQuery.Data))
+		if reqQuery.Prove || reqQuery.Height != 0 {
+			if id, found := candidateIdAt(tree, address, height); found {
+				queryRecords(tree, stake.VerificationsPrefix(id), height, reqQuery.Prove, func([]byte) bool { return true }, &resQuery)
+			} else {
+				resQuery.Value = []byte("null")
+			}
+			break
+		}
+		resQuery.Height = app.CommittedHeight()
+		verifications := stake.QueryCandidateVerifications(address)
+		b, _ := json.Marshal(verifications)
+		resQuery.Value = b
+	case "/delegator":
+		address := common.HexToAddress(string(reqQuery.Data))
+		if reqQuery.Prove || reqQuery.Height != 0 {
+			queryRecords(tree, utils.DelegationPrefix, height, reqQuery.Prove, func(b []byte) bool {
+				var d stake.Delegation
+				return json.Unmarshal(b, &d) == nil && d.DelegatorAddress == address
+			}, &resQuery)
+			break
+		}
+		resQuery.Height = app.CommittedHeight()
+		delegations := stake.QueryDelegationsByDelegator(address)
+		b, _ := json.Marshal(delegations)
+		resQuery.Value = b
+	case "/account_update_requests/candidate":
+		address := common.HexToAddress(string(reqQuery.Data))
+		if reqQuery.Prove || reqQuery.Height != 0 {
+			if id, found := candidateIdAt(tree, address, height); found {
+				queryRecords(tree, utils.AccountUpdatePrefix, height, reqQuery.Prove, func(b []byte) bool {
+					var req stake.CandidateAccountUpdateRequest
+					return json.Unmarshal(b, &req) == nil && req.CandidateId == id && req.State == "PENDING"
+				}, &resQuery)
+			} else {
+				resQuery.Value = []byte("null")
+			}
+			break
+		}
+		resQuery.Height = app.CommittedHeight()
+		reqs := stake.QueryPendingAccountUpdateRequestsByCandidate(address)
+		b, _ := json.Marshal(reqs)
+		resQuery.Value = b
+	case "/account_update_requests/to":
+		address := common.HexToAddress(string(reqQuery.Data))
+		if reqQuery.Prove || reqQuery.Height != 0 {
+			queryRecords(tree, utils.AccountUpdatePrefix, height, reqQuery.Prove, func(b []byte) bool {
+				var req stake.CandidateAccountUpdateRequest
+				return json.Unmarshal(b, &req) == nil && req.ToAddress == address && req.State == "PENDING"
+			}, &resQuery)
+			break
+		}
+		resQuery.Height = app.CommittedHeight()
+		reqs := stake.QueryPendingAccountUpdateRequestsByToAddress(address)
+		b, _ := json.Marshal(reqs)
+		resQuery.Value = b
+	case "/unbondings":
+		address := common.HexToAddress(string(reqQuery.Data))
+		if reqQuery.Prove || reqQuery.Height != 0 {
+			queryRecords(tree, utils.UnbondingPrefix, height, reqQuery.Prove, func(b []byte) bool {
+				var u stake.Unbonding
+				return json.Unmarshal(b, &u) == nil && u.DelegatorAddress == address
+			}, &resQuery)
+			break
+		}
+		resQuery.Height = app.CommittedHeight()
+		unbondings := stake.QueryUnbondingsByDelegator(address)
+		b, _ := json.Marshal(unbondings)
+		resQuery.Value = b
+	case "/fee_distributions":
+		h := reqQuery.Height
+		if h == 0 {
+			h = app.CommittedHeight()
+		}
+		resQuery.Height = h
+		fds := stake.QueryFeeDistributions(h)
+		b, _ := json.Marshal(fds)
+		resQuery.Value = b
+	case "/governance/proposal":
+		// the record of the store leaves out the download status of a library
+		// kept by each node, which the database holds
+		if reqQuery.Prove || reqQuery.Height != 0 {
+			queryRecord(tree, governance.ProposalKey(string(reqQuery.Data)), height, reqQuery.Prove, &resQuery)
+			break
+		}
+		resQuery.Height = app.CommittedHeight()
+		proposal := governance.QueryProposal(string(reqQuery.Data))
+		if proposal != nil {
+			b, _ := json.Marshal(proposal)
+			resQuery.Value = b
+		} else {
+			resQuery.Value = []byte{}
+		}
+	case "/governance/votes":
+		if reqQuery.Prove || reqQuery.Height != 0 {
+			queryRecords(tree, governance.VotesPrefix(string(reqQuery.Data)), height, reqQuery.Prove, func([]byte) bool { return true }, &resQuery)
+			break
+		}
+		resQuery.Height = app.CommittedHeight()
+		votes := governance.QueryVotes(string(reqQuery.Data))
+		b, _ := json.Marshal(votes)
+		resQuery.Value = b
+	case "/governance/tally":
+		tally := governance.QueryTally(string(reqQuery.Data))
+		if tally != nil {
+			b, _ := json.Marshal(tally)
+			resQuery.Value = b
 		} else {
 			resQuery.Value = []byte{}
 		}
+	case "/governance/param_changes":
+		// Data holds the param name, empty for all the params
+		logs := governance.QueryParamChangeLogs(governance.ParamChangesQueryName(reqQuery.Data))
+		b, _ := json.Marshal(logs)
+		resQuery.Value = b
+	case "/scheduled_txs":
+		// Data holds the hash of the transaction which scheduled the calls
+		txs := schedule.QueryScheduledTxs(common.BytesToHash(reqQuery.Data))
+		b, _ := json.Marshal(txs)
+		resQuery.Value = b
 	case "/governance/proposals":
-		proposals := governance.QueryProposals()
+		// Data optionally holds a json encoded filter
+		var proposals []*governance.Proposal
+		var filter governance.ProposalFilter
+		if err := json.Unmarshal(reqQuery.Data, &filter); err == nil {
+			proposals = governance.QueryProposalsByFilter(&filter)
+		} else {
+			proposals = governance.QueryProposals()
+		}
 		b, _ := json.Marshal(proposals)
 		resQuery.Value = b
 	default:
@@ -211,6 +391,79 @@ func (app *StoreApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQu
 	return
 }
 
+// queryRecord answers with the record kept in the store under the key at the height,
+// and its proof when asked for. A missing record is an empty value.
+func queryRecord(tree *sm.Bonsai, key []byte, height int64, prove bool, resQuery *abci.ResponseQuery) {
+	resQuery.Key = key
+	if prove {
+		value, proof, err := tree.GetVersionedWithProof(key, height)
+		if err != nil {
+			resQuery.Code = errors.CodeTypeBaseInvalidInput
+			resQuery.Log = err.Error()
+			return
+		}
+		resQuery.Value = value
+		resQuery.Proof = proof.ComputeRootHash()
+	} else {
+		_, resQuery.Value = tree.GetVersioned(key, height)
+	}
+	if resQuery.Value == nil {
+		resQuery.Value = []byte{}
+	}
+}
+
+// queryRecords answers with the records kept in the store under the prefix at the height
+// which match, as a json list. The proof covers all the records under the prefix, so
+// that the ones left out can be checked too.
+func queryRecords(tree *sm.Bonsai, prefix []byte, height int64, prove bool, match func([]byte) bool, resQuery *abci.ResponseQuery) {
+	resQuery.Key = prefix
+	_, values, proof, err := tree.GetVersionedRangeWithProof(prefix, prefixEnd(prefix), 0, height)
+	if err != nil {
+		resQuery.Code = errors.CodeTypeBaseInvalidInput
+		resQuery.Log = err.Error()
+		return
+	}
+
+	records := []json.RawMessage{}
+	for _, value := range values {
+		if match(value) {
+			records = append(records, value)
+		}
+	}
+	resQuery.Value, _ = json.Marshal(records)
+	if prove {
+		resQuery.Proof = proof.ComputeRootHash()
+	}
+}
+
+// candidateIdAt finds the candidate of the owner address in the store at the height
+func candidateIdAt(tree *sm.Bonsai, address common.Address, height int64) (int64, bool) {
+	_, values, _, err := tree.GetVersionedRangeWithProof(utils.CandidatePrefix, prefixEnd(utils.CandidatePrefix), 0, height)
+	if err != nil {
+		return 0, false
+	}
+
+	for _, value := range values {
+		var c stake.Candidate
+		if json.Unmarshal(value, &c) == nil && common.HexToAddress(c.OwnerAddress) == address {
+			return c.Id, true
+		}
+	}
+	return 0, false
+}
+
+// prefixEnd is the first key after the keys starting with the prefix
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
 // Commit implements abci.Application
 func (app *StoreApp) Commit() (res abci.ResponseCommit) {
 	app.height++
@@ -305,11 +558,73 @@ func (app *StoreApp) GetOldDbHash() []byte {
 	return hashing(hashes)
 }
 
-func (app *StoreApp) GetDbHash() []byte {
+// SetIncrementalDbHashHeight records the height from which the database hash is
+// maintained incrementally, it is set once from the genesis or at the upgrade height
+func (app *StoreApp) SetIncrementalDbHashHeight(height int64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(height))
+	app.Append().Set(utils.DbHashHeightKey, b)
+	app.dbHashHeight = height
+}
+
+// UpgradeIncrementalDbHash switches a chain started before the incremental database
+// hash over to it at the height, which all the nodes must be given before the chain
+// reaches it. The blocks before it keep the hash of all the rows.
+func (app *StoreApp) UpgradeIncrementalDbHash(height int64) error {
+	if from := app.recordedDbHashHeight(); from > 0 {
+		if from != height {
+			return errors.ErrInternal(cmn.Fmt("The database hash is incremental from height %d already", from))
+		}
+		return nil
+	}
+	if height <= app.CommittedHeight() {
+		return errors.ErrInternal(cmn.Fmt("The incremental database hash height %d must be after the committed height %d", height, app.CommittedHeight()))
+	}
+	app.dbHashUpgradeHeight = height
+	return nil
+}
+
+// recordDbHashUpgrade records the upgrade height in the store by the commit of its block,
+// so that it is part of the state of every node at the same height
+func (app *StoreApp) recordDbHashUpgrade(workingHeight int64) {
+	if app.dbHashUpgradeHeight == workingHeight && app.recordedDbHashHeight() == 0 {
+		app.SetIncrementalDbHashHeight(workingHeight)
+	}
+}
+
+// the chains started before the incremental database hash have no height recorded,
+// their database hash is computed from all the rows until they are upgraded
+func (app *StoreApp) recordedDbHashHeight() int64 {
+	if app.dbHashHeight == 0 {
+		if b := app.state.Committed().Get(utils.DbHashHeightKey); len(b) == 8 {
+			app.dbHashHeight = int64(binary.BigEndian.Uint64(b))
+		}
+	}
+	return app.dbHashHeight
+}
+
+func (app *StoreApp) incrementalDbHashHeight() int64 {
+	if from := app.recordedDbHashHeight(); from > 0 {
+		return from
+	}
+	return app.dbHashUpgradeHeight
+}
+
+// GetDbHash returns the hash of the database at the height, folded from the accumulators
+// of the tables from the height set in the genesis or by the upgrade on, and from all
+// the rows of the tables before so the earlier heights still verify
+func (app *StoreApp) GetDbHash(height int64) []byte {
 	db, _ := dbm.Sqliter.GetDB()
-	tables := []string{"candidates", "governance_proposal", "governance_vote", "candidate_account_update_requests"}
-	hashes := make([]byte, len(tables))
-	for _, table := range tables {
+	if from := app.incrementalDbHashHeight(); from > 0 && height >= from {
+		return getIncrementalDbHash(db)
+	}
+	return getFullDbHash(db)
+}
+
+// getFullDbHash hashes all the rows of the hashed tables
+func getFullDbHash(db *sql.DB) []byte {
+	hashes := make([]byte, len(dbHashTables))
+	for _, table := range dbHashTables {
 		hashes = append(hashes, getTableHash(db, table)...)
 	}
 	return hashing(hashes)
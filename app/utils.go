@@ -16,6 +16,7 @@ import (
 
 	"github.com/second-state/devchain/errors"
 	"github.com/second-state/devchain/utils"
+	emtTypes "github.com/second-state/devchain/vm/types"
 )
 
 // format of query data
@@ -41,16 +42,22 @@ func decodeTx(txBytes []byte) (*types.Transaction, error) {
 // Receiver returns the receiving address based on the selected strategy
 // #unstable
 func (app *EthermintApplication) Receiver() common.Address {
-	if app.strategy != nil {
+	if app.strategy != nil && app.strategy.MinerRewardStrategy != nil {
 		return app.strategy.Receiver()
 	}
 	return utils.HoldAccount
 }
 
+// AwardInfos returns the rewards awarded by the strategy in the current block
+// #unstable
+func (app *EthermintApplication) AwardInfos() []emtTypes.AwardInfo {
+	return app.awardInfos
+}
+
 // SetValidators sets new validators on the strategy
 // #unstable
 func (app *EthermintApplication) SetValidators(validators []abciTypes.Validator) {
-	if app.strategy != nil {
+	if app.strategy != nil && app.strategy.ValidatorsStrategy != nil {
 		app.strategy.SetValidators(validators)
 	}
 }
@@ -58,7 +65,7 @@ func (app *EthermintApplication) SetValidators(validators []abciTypes.Validator)
 // GetUpdatedValidators returns an updated validator set from the strategy
 // #unstable
 func (app *EthermintApplication) GetUpdatedValidators() abciTypes.ResponseEndBlock {
-	if app.strategy != nil {
+	if app.strategy != nil && app.strategy.ValidatorsStrategy != nil {
 		return abciTypes.ResponseEndBlock{ValidatorUpdates: app.strategy.GetUpdatedValidators()}
 	}
 	return abciTypes.ResponseEndBlock{}
@@ -67,7 +74,7 @@ func (app *EthermintApplication) GetUpdatedValidators() abciTypes.ResponseEndBlo
 // CollectTx invokes CollectTx on the strategy
 // #unstable
 func (app *EthermintApplication) CollectTx(tx *types.Transaction) {
-	if app.strategy != nil {
+	if app.strategy != nil && app.strategy.ValidatorsStrategy != nil {
 		app.strategy.CollectTx(tx)
 	}
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	govcmd "github.com/second-state/devchain/modules/governance/commands"
 	stakecmd "github.com/second-state/devchain/modules/stake/commands"
 	"github.com/second-state/devchain/sdk/client/commands"
 	"github.com/second-state/devchain/sdk/client/commands/query"
@@ -24,6 +25,13 @@ func prepareClientCommands() {
 	query.RootCmd.AddCommand(
 		stakecmd.CmdQueryValidator,
 		stakecmd.CmdQueryValidators,
+		stakecmd.CmdQueryDelegator,
+		govcmd.CmdQueryProposals,
+		govcmd.CmdQueryProposal,
+		govcmd.CmdQueryVotes,
+		govcmd.CmdQueryTally,
+		govcmd.CmdQueryParams,
+		govcmd.CmdQueryParamChanges,
 	)
 
 	// set up the middleware
@@ -39,6 +47,13 @@ func prepareClientCommands() {
 		stakecmd.CmdDeactivateCandidacy,
 		stakecmd.CmdUpdateCandidacyAccount,
 		stakecmd.CmdAcceptCandidacyAccountUpdate,
+		stakecmd.CmdCancelCandidacyAccountUpdate,
+		stakecmd.CmdDelegate,
+		stakecmd.CmdUnbond,
+		stakecmd.CmdUnjail,
+		govcmd.CmdPropose,
+		govcmd.CmdVote,
+		govcmd.CmdCancelProposal,
 	)
 
 	clientCmd.AddCommand(
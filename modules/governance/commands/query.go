@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/second-state/devchain/modules/governance"
+	stakecmd "github.com/second-state/devchain/modules/stake/commands"
+	"github.com/second-state/devchain/utils"
+)
+
+/**
+The governance/query/proposals is to query all the proposals. Not signed.
+
+The governance/query/proposal is to query a proposal by its ID. Not signed.
+
+* Proposal ID
+
+The governance/query/votes is to query the votes on a proposal. Not signed.
+
+* Proposal ID
+
+The governance/query/tally is to query the current tally of a proposal. Not signed.
+
+* Proposal ID
+
+The governance/query/params is to query the system parameters. Not signed.
+
+* Block height
+
+The governance/query/param-changes is to query the changes of the system parameters. Not signed.
+
+* Parameter name
+*/
+
+// nolint
+var (
+	CmdQueryProposals = &cobra.Command{
+		Use:   "proposals",
+		RunE:  cmdQueryProposals,
+		Short: "Query all the governance proposals",
+	}
+
+	CmdQueryProposal = &cobra.Command{
+		Use:   "proposal",
+		RunE:  cmdQueryProposal,
+		Short: "Query a governance proposal",
+	}
+
+	CmdQueryVotes = &cobra.Command{
+		Use:   "votes",
+		RunE:  cmdQueryVotes,
+		Short: "Query the votes on a governance proposal",
+	}
+
+	CmdQueryTally = &cobra.Command{
+		Use:   "tally",
+		RunE:  cmdQueryTally,
+		Short: "Query the current tally of a governance proposal",
+	}
+
+	CmdQueryParams = &cobra.Command{
+		Use:   "params",
+		RunE:  cmdQueryParams,
+		Short: "Query the system parameters",
+	}
+
+	CmdQueryParamChanges = &cobra.Command{
+		Use:   "param-changes",
+		RunE:  cmdQueryParamChanges,
+		Short: "Query the changes of the system parameters made by proposals",
+	}
+)
+
+func init() {
+	//Add Flags
+	fsPid := flag.NewFlagSet("", flag.ContinueOnError)
+	fsPid.String(FlagProposalId, "", "proposal ID")
+
+	fsHeight := flag.NewFlagSet("", flag.ContinueOnError)
+	fsHeight.Int64(stakecmd.FlagHeight, 0, "block height, the latest committed block if not set")
+
+	CmdQueryProposal.Flags().AddFlagSet(fsPid)
+	CmdQueryVotes.Flags().AddFlagSet(fsPid)
+	CmdQueryTally.Flags().AddFlagSet(fsPid)
+	CmdQueryParams.Flags().AddFlagSet(fsHeight)
+	CmdQueryParamChanges.Flags().String(FlagName, "", "parameter name, all the parameters if not set")
+}
+
+func cmdQueryProposals(cmd *cobra.Command, args []string) error {
+	b, err := stakecmd.Get("/governance/proposals", []byte{0})
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryProposal(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if pid == "" {
+		return fmt.Errorf("please enter proposal ID using --proposal-id")
+	}
+
+	b, err := stakecmd.Get("/governance/proposal", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryVotes(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if pid == "" {
+		return fmt.Errorf("please enter proposal ID using --proposal-id")
+	}
+
+	b, err := stakecmd.Get("/governance/votes", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryTally(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if pid == "" {
+		return fmt.Errorf("please enter proposal ID using --proposal-id")
+	}
+
+	b, err := stakecmd.Get("/governance/tally", []byte(pid))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryParams(cmd *cobra.Command, args []string) error {
+	b, err := stakecmd.GetByHeight("/key", utils.ParamKey, viper.GetInt64(stakecmd.FlagHeight))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
+
+func cmdQueryParamChanges(cmd *cobra.Command, args []string) error {
+	b, err := stakecmd.Get("/governance/param_changes", governance.ParamChangesQueryData(viper.GetString(FlagName)))
+	if err != nil {
+		return err
+	}
+	return stakecmd.Foutput(b)
+}
@@ -0,0 +1,410 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/second-state/devchain/modules/governance"
+	"github.com/second-state/devchain/sdk"
+	txcmd "github.com/second-state/devchain/sdk/client/commands/txs"
+	"github.com/second-state/devchain/utils"
+)
+
+/*
+The governance/propose/* txs allow a validator to submit a proposal, the other validators
+vote on it with the governance/vote tx. Signed by the validator.
+
+The governance/cancel tx allows the proposer to cancel its proposal before it is decided.
+*/
+
+// nolint
+const (
+	FlagTransferFrom        = "transfer-from"
+	FlagTransferTo          = "transfer-to"
+	FlagAmount              = "amount"
+	FlagReason              = "reason"
+	FlagExpireTimestamp     = "expire-timestamp"
+	FlagExpireBlockHeight   = "expire-block-height"
+	FlagName                = "name"
+	FlagValue               = "value"
+	FlagVersion             = "version"
+	FlagFileUrl             = "file-url"
+	FlagMd5                 = "md5"
+	FlagDeployTimestamp     = "deploy-timestamp"
+	FlagDeployBlockHeight   = "deploy-block-height"
+	FlagPreservedValidators = "preserved-validators"
+	FlagRetiredBlockHeight  = "retired-block-height"
+	FlagUpgradeBlockHeight  = "upgrade-block-height"
+	FlagContractAddress     = "contract-address"
+	FlagData                = "data"
+	FlagGasLimit            = "gas-limit"
+	FlagProposalId          = "proposal-id"
+	FlagTranches            = "tranches"
+	FlagInterval            = "interval"
+	FlagGrantId             = "grant-id"
+	FlagAnswer              = "answer"
+)
+
+// nolint
+var (
+	CmdPropose = &cobra.Command{
+		Use:   "propose",
+		Short: "Submit a governance proposal",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+	CmdProposeTransferFund = &cobra.Command{
+		Use:   "transfer-fund",
+		Short: "Propose to transfer CMTs from an account to another",
+		RunE:  cmdProposeTransferFund,
+	}
+	CmdProposeGrant = &cobra.Command{
+		Use:   "grant",
+		Short: "Propose to pay CMTs in tranches at a block interval",
+		RunE:  cmdProposeGrant,
+	}
+	CmdProposeRevokeGrant = &cobra.Command{
+		Use:   "revoke-grant",
+		Short: "Propose to stop paying a grant and refund its unpaid remainder",
+		RunE:  cmdProposeRevokeGrant,
+	}
+	CmdProposeChangeParam = &cobra.Command{
+		Use:   "change-param",
+		Short: "Propose to change a system parameter",
+		RunE:  cmdProposeChangeParam,
+	}
+	CmdProposeChangeParams = &cobra.Command{
+		Use:   "change-params name=value...",
+		Short: "Propose to change several system parameters at once, all of them are changed or none",
+		RunE:  cmdProposeChangeParams,
+	}
+	CmdProposeDeployLibEni = &cobra.Command{
+		Use:   "deploy-libeni",
+		Short: "Propose to deploy a libENI library",
+		RunE:  cmdProposeDeployLibEni,
+	}
+	CmdProposeRetireProgram = &cobra.Command{
+		Use:   "retire-program",
+		Short: "Propose to retire the running program version",
+		RunE:  cmdProposeRetireProgram,
+	}
+	CmdProposeUpgradeProgram = &cobra.Command{
+		Use:   "upgrade-program",
+		Short: "Propose to upgrade the program to a new version",
+		RunE:  cmdProposeUpgradeProgram,
+	}
+	CmdProposeCallContract = &cobra.Command{
+		Use:   "call-contract",
+		Short: "Propose to call a contract from the governance account",
+		RunE:  cmdProposeCallContract,
+	}
+	CmdVote = &cobra.Command{
+		Use:   "vote",
+		Short: "Vote on a proposal",
+		RunE:  cmdVote,
+	}
+	CmdCancelProposal = &cobra.Command{
+		Use:   "cancel-proposal",
+		Short: "Allows the proposer to cancel an undecided proposal",
+		RunE:  cmdCancelProposal,
+	}
+)
+
+func init() {
+
+	// define the flags
+	fsReason := flag.NewFlagSet("", flag.ContinueOnError)
+	fsReason.String(FlagReason, "", "Reason of the proposal")
+
+	fsExpire := flag.NewFlagSet("", flag.ContinueOnError)
+	fsExpire.Int64(FlagExpireTimestamp, 0, "Timestamp when the proposal expires")
+	fsExpire.Int64(FlagExpireBlockHeight, 0, "Block height when the proposal expires")
+
+	fsTransfer := flag.NewFlagSet("", flag.ContinueOnError)
+	fsTransfer.String(FlagTransferFrom, "", "Account the CMTs are transferred from")
+	fsTransfer.String(FlagTransferTo, "", "Account the CMTs are transferred to")
+	fsTransfer.String(FlagAmount, "", "Amount of CMTs")
+
+	fsGrant := flag.NewFlagSet("", flag.ContinueOnError)
+	fsGrant.Uint64(FlagTranches, 1, "Number of tranches the amount is paid in")
+	fsGrant.Int64(FlagInterval, 0, "Number of blocks between two tranches")
+
+	fsGrantId := flag.NewFlagSet("", flag.ContinueOnError)
+	fsGrantId.String(FlagGrantId, "", "ID of the grant proposal")
+
+	fsParam := flag.NewFlagSet("", flag.ContinueOnError)
+	fsParam.String(FlagName, "", "Name of the parameter")
+	fsParam.String(FlagValue, "", "New value of the parameter")
+
+	fsProgram := flag.NewFlagSet("", flag.ContinueOnError)
+	fsProgram.String(FlagName, "", "Name of the library or program")
+	fsProgram.String(FlagVersion, "", "Version of the library or program")
+	fsProgram.String(FlagFileUrl, "", "Download urls per OS, in json")
+	fsProgram.String(FlagMd5, "", "Md5 checksums per OS, in json")
+
+	fsDeploy := flag.NewFlagSet("", flag.ContinueOnError)
+	fsDeploy.Int64(FlagDeployTimestamp, 0, "Timestamp when the library is deployed")
+	fsDeploy.Int64(FlagDeployBlockHeight, 0, "Block height when the library is deployed")
+
+	fsRetire := flag.NewFlagSet("", flag.ContinueOnError)
+	fsRetire.String(FlagPreservedValidators, "", "Comma separated public keys of the validators kept after retirement")
+	fsRetire.Int64(FlagRetiredBlockHeight, 0, "Block height when the program is retired")
+
+	fsUpgrade := flag.NewFlagSet("", flag.ContinueOnError)
+	fsUpgrade.Int64(FlagUpgradeBlockHeight, 0, "Block height when the program is upgraded")
+
+	fsContract := flag.NewFlagSet("", flag.ContinueOnError)
+	fsContract.String(FlagContractAddress, "", "Address of the contract")
+	fsContract.String(FlagValue, "0", "Amount of CMTs sent with the call, escrowed from the proposer")
+	fsContract.String(FlagData, "", "Hex encoded call data")
+	fsContract.Uint64(FlagGasLimit, 0, "Gas limit of the call, the call_contract_gas_limit param if not set")
+
+	fsProposalId := flag.NewFlagSet("", flag.ContinueOnError)
+	fsProposalId.String(FlagProposalId, "", "Proposal ID")
+
+	fsAnswer := flag.NewFlagSet("", flag.ContinueOnError)
+	fsAnswer.String(FlagAnswer, "", "Y (yes), N (no), A (abstain) or V (no with veto)")
+
+	// add the flags
+	CmdProposeTransferFund.Flags().AddFlagSet(fsTransfer)
+	CmdProposeTransferFund.Flags().AddFlagSet(fsReason)
+	CmdProposeTransferFund.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeGrant.Flags().AddFlagSet(fsTransfer)
+	CmdProposeGrant.Flags().AddFlagSet(fsGrant)
+	CmdProposeGrant.Flags().AddFlagSet(fsReason)
+	CmdProposeGrant.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeRevokeGrant.Flags().AddFlagSet(fsGrantId)
+	CmdProposeRevokeGrant.Flags().AddFlagSet(fsReason)
+	CmdProposeRevokeGrant.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeChangeParam.Flags().AddFlagSet(fsParam)
+	CmdProposeChangeParam.Flags().AddFlagSet(fsReason)
+	CmdProposeChangeParam.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeChangeParams.Flags().AddFlagSet(fsReason)
+	CmdProposeChangeParams.Flags().AddFlagSet(fsExpire)
+
+	CmdProposeDeployLibEni.Flags().AddFlagSet(fsProgram)
+	CmdProposeDeployLibEni.Flags().AddFlagSet(fsReason)
+	CmdProposeDeployLibEni.Flags().AddFlagSet(fsDeploy)
+
+	CmdProposeRetireProgram.Flags().AddFlagSet(fsRetire)
+	CmdProposeRetireProgram.Flags().AddFlagSet(fsReason)
+
+	CmdProposeUpgradeProgram.Flags().AddFlagSet(fsProgram)
+	CmdProposeUpgradeProgram.Flags().AddFlagSet(fsReason)
+	CmdProposeUpgradeProgram.Flags().AddFlagSet(fsUpgrade)
+
+	CmdProposeCallContract.Flags().AddFlagSet(fsContract)
+	CmdProposeCallContract.Flags().AddFlagSet(fsReason)
+	CmdProposeCallContract.Flags().AddFlagSet(fsExpire)
+
+	CmdVote.Flags().AddFlagSet(fsProposalId)
+	CmdVote.Flags().AddFlagSet(fsAnswer)
+
+	CmdCancelProposal.Flags().AddFlagSet(fsProposalId)
+
+	CmdPropose.AddCommand(
+		CmdProposeTransferFund,
+		CmdProposeGrant,
+		CmdProposeRevokeGrant,
+		CmdProposeChangeParam,
+		CmdProposeChangeParams,
+		CmdProposeDeployLibEni,
+		CmdProposeRetireProgram,
+		CmdProposeUpgradeProgram,
+		CmdProposeCallContract,
+	)
+}
+
+func cmdProposeTransferFund(cmd *cobra.Command, args []string) error {
+	if utils.IsBlank(viper.GetString(FlagTransferFrom)) || utils.IsBlank(viper.GetString(FlagTransferTo)) {
+		return fmt.Errorf("please enter the accounts using --transfer-from and --transfer-to")
+	}
+	from := common.HexToAddress(viper.GetString(FlagTransferFrom))
+	to := common.HexToAddress(viper.GetString(FlagTransferTo))
+
+	amount := viper.GetString(FlagAmount)
+	if v, ok := sdk.NewIntFromString(amount); !ok || v.LTE(sdk.ZeroInt) {
+		return fmt.Errorf("please enter a positive amount using --amount")
+	}
+
+	tx := governance.NewTxTransferFundPropose(&from, &to, amount, viper.GetString(FlagReason),
+		getInt64Flag(FlagExpireTimestamp), getInt64Flag(FlagExpireBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeGrant(cmd *cobra.Command, args []string) error {
+	if utils.IsBlank(viper.GetString(FlagTransferFrom)) || utils.IsBlank(viper.GetString(FlagTransferTo)) {
+		return fmt.Errorf("please enter the accounts using --transfer-from and --transfer-to")
+	}
+	from := common.HexToAddress(viper.GetString(FlagTransferFrom))
+	to := common.HexToAddress(viper.GetString(FlagTransferTo))
+
+	amount := viper.GetString(FlagAmount)
+	if v, ok := sdk.NewIntFromString(amount); !ok || v.LTE(sdk.ZeroInt) {
+		return fmt.Errorf("please enter a positive amount using --amount")
+	}
+
+	tranches := uint64(viper.GetInt64(FlagTranches))
+	interval := viper.GetInt64(FlagInterval)
+	if tranches == 0 || interval <= 0 {
+		return fmt.Errorf("please enter positive tranches and interval using --tranches and --interval")
+	}
+
+	tx := governance.NewTxGrantPropose(&from, &to, amount, tranches, interval, viper.GetString(FlagReason),
+		getInt64Flag(FlagExpireTimestamp), getInt64Flag(FlagExpireBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeRevokeGrant(cmd *cobra.Command, args []string) error {
+	grantId := viper.GetString(FlagGrantId)
+	if utils.IsBlank(grantId) {
+		return fmt.Errorf("please enter the grant ID using --grant-id")
+	}
+
+	tx := governance.NewTxRevokeGrantPropose(grantId, viper.GetString(FlagReason),
+		getInt64Flag(FlagExpireTimestamp), getInt64Flag(FlagExpireBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeChangeParam(cmd *cobra.Command, args []string) error {
+	name := viper.GetString(FlagName)
+	if utils.IsBlank(name) {
+		return fmt.Errorf("please enter the parameter name using --name")
+	}
+
+	tx := governance.NewTxChangeParamPropose(name, viper.GetString(FlagValue), viper.GetString(FlagReason),
+		getInt64Flag(FlagExpireTimestamp), getInt64Flag(FlagExpireBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeChangeParams(cmd *cobra.Command, args []string) error {
+	var params []utils.ParamChange
+	for _, pair := range args {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || utils.IsBlank(kv[0]) {
+			return fmt.Errorf("invalid parameter %q, expected name=value", pair)
+		}
+		params = append(params, utils.ParamChange{Name: kv[0], Value: kv[1]})
+	}
+	if len(params) == 0 {
+		return fmt.Errorf("please enter the parameters as name=value arguments")
+	}
+
+	tx := governance.NewTxChangeParamsPropose(params, viper.GetString(FlagReason),
+		getInt64Flag(FlagExpireTimestamp), getInt64Flag(FlagExpireBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeDeployLibEni(cmd *cobra.Command, args []string) error {
+	name, version, fileUrl, md5, err := getProgramParams()
+	if err != nil {
+		return err
+	}
+
+	tx := governance.NewTxDeployLibEniPropose(name, version, fileUrl, md5, viper.GetString(FlagReason),
+		getInt64Flag(FlagDeployTimestamp), getInt64Flag(FlagDeployBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeRetireProgram(cmd *cobra.Command, args []string) error {
+	preservedValidators := viper.GetString(FlagPreservedValidators)
+	if utils.IsBlank(preservedValidators) {
+		return fmt.Errorf("please enter the preserved validators using --preserved-validators")
+	}
+
+	tx := governance.NewTxRetireProgramPropose(preservedValidators, viper.GetString(FlagReason),
+		getInt64Flag(FlagRetiredBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeUpgradeProgram(cmd *cobra.Command, args []string) error {
+	name, version, fileUrl, md5, err := getProgramParams()
+	if err != nil {
+		return err
+	}
+
+	tx := governance.NewTxUpgradeProgramPropose(name, version, fileUrl, md5, viper.GetString(FlagReason),
+		getInt64Flag(FlagUpgradeBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeCallContract(cmd *cobra.Command, args []string) error {
+	if utils.IsBlank(viper.GetString(FlagContractAddress)) {
+		return fmt.Errorf("please enter the contract address using --contract-address")
+	}
+	contractAddress := common.HexToAddress(viper.GetString(FlagContractAddress))
+
+	value := viper.GetString(FlagValue)
+	if v, ok := sdk.NewIntFromString(value); !ok || v.LT(sdk.ZeroInt) {
+		return fmt.Errorf("please enter a non-negative value using --value")
+	}
+
+	data := viper.GetString(FlagData)
+	if _, err := governance.DecodeCallData(data); err != nil {
+		return fmt.Errorf("please enter hex encoded call data using --data")
+	}
+
+	tx := governance.NewTxCallContractPropose(&contractAddress, value, data, uint64(viper.GetInt64(FlagGasLimit)),
+		viper.GetString(FlagReason), getInt64Flag(FlagExpireTimestamp), getInt64Flag(FlagExpireBlockHeight))
+	return txcmd.DoTx(tx)
+}
+
+func cmdVote(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter the proposal ID using --proposal-id")
+	}
+
+	answer := viper.GetString(FlagAnswer)
+	if !governance.IsValidVoteAnswer(answer) {
+		return fmt.Errorf("please enter Y, N, A or V using --answer")
+	}
+
+	tx := governance.NewTxVote(pid, answer)
+	return txcmd.DoTx(tx)
+}
+
+func cmdCancelProposal(cmd *cobra.Command, args []string) error {
+	pid := viper.GetString(FlagProposalId)
+	if utils.IsBlank(pid) {
+		return fmt.Errorf("please enter the proposal ID using --proposal-id")
+	}
+
+	tx := governance.NewTxCancelProposal(pid)
+	return txcmd.DoTx(tx)
+}
+
+func getProgramParams() (name, version, fileUrl, md5 string, err error) {
+	name = viper.GetString(FlagName)
+	version = viper.GetString(FlagVersion)
+	if utils.IsBlank(name) || utils.IsBlank(version) {
+		return "", "", "", "", fmt.Errorf("please enter the name and version using --name and --version")
+	}
+
+	fileUrl = viper.GetString(FlagFileUrl)
+	md5 = viper.GetString(FlagMd5)
+	if utils.IsBlank(fileUrl) || utils.IsBlank(md5) {
+		return "", "", "", "", fmt.Errorf("please enter the file urls and md5 checksums using --file-url and --md5")
+	}
+
+	return name, version, fileUrl, md5, nil
+}
+
+// getInt64Flag returns nil if the flag is not set
+func getInt64Flag(name string) *int64 {
+	v := viper.GetInt64(name)
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
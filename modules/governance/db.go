@@ -1,11 +1,14 @@
 package governance
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"database/sql"
 	"github.com/second-state/devchain/sdk/dbm"
+	"github.com/second-state/devchain/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -70,6 +73,7 @@ func (wrapper *SqlTxWrapper) Rollback() {
 func SaveProposal(pp *Proposal) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
+	touchedProposals[pp.Id] = true
 
 	stmt, err := txWrapper.tx.Prepare("insert into governance_proposal(id, type, proposer, block_height, expire_timestamp, expire_block_height, hash) values(?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
@@ -144,6 +148,55 @@ func SaveProposal(pp *Proposal) {
 			fmt.Println(err)
 			panic(err)
 		}
+	case CALL_CONTRACT_PROPOSAL:
+		stmt1, err := txWrapper.tx.Prepare("insert into governance_call_contract_detail(proposal_id, contract_address, value, calldata, gas_limit, reason, status, gas_used, return_data, tx_hash, logs) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		if err != nil {
+			panic(err)
+		}
+		defer stmt1.Close()
+
+		_, err = stmt1.Exec(pp.Id, pp.Detail["contract_address"].(*common.Address).String(), pp.Detail["value"], pp.Detail["data"], pp.Detail["gas_limit"], pp.Detail["reason"], pp.Detail["status"], pp.Detail["gas_used"], pp.Detail["return_data"], pp.Detail["tx_hash"], pp.Detail["logs"])
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	case CHANGE_PARAMS_PROPOSAL:
+		stmt1, err := txWrapper.tx.Prepare("insert into governance_change_params_detail(proposal_id, params, reason) values(?, ?, ?)")
+		if err != nil {
+			panic(err)
+		}
+		defer stmt1.Close()
+
+		params, _ := json.Marshal(pp.Detail["params"])
+		_, err = stmt1.Exec(pp.Id, string(params), pp.Detail["reason"])
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	case GRANT_PROPOSAL:
+		stmt1, err := txWrapper.tx.Prepare("insert into governance_grant_detail(proposal_id, from_address, to_address, amount, tranches, interval, reason, status, paid_amount, paid_tranches, next_block_height) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		if err != nil {
+			panic(err)
+		}
+		defer stmt1.Close()
+
+		_, err = stmt1.Exec(pp.Id, pp.Detail["from"].(*common.Address).String(), pp.Detail["to"].(*common.Address).String(), pp.Detail["amount"], pp.Detail["tranches"], pp.Detail["interval"], pp.Detail["reason"], pp.Detail["status"], pp.Detail["paid_amount"], pp.Detail["paid_tranches"], pp.Detail["next_block_height"])
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	case REVOKE_GRANT_PROPOSAL:
+		stmt1, err := txWrapper.tx.Prepare("insert into governance_revoke_grant_detail(proposal_id, grant_id, reason) values(?, ?, ?)")
+		if err != nil {
+			panic(err)
+		}
+		defer stmt1.Close()
+
+		_, err = stmt1.Exec(pp.Id, pp.Detail["grant_id"], pp.Detail["reason"])
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
 	}
 }
 
@@ -336,6 +389,153 @@ func GetProposalById(pid string) *Proposal {
 				"reason": reason,
 			},
 		}
+	case CALL_CONTRACT_PROPOSAL:
+		var contractAddr, value, data, reason, status, returnData, txHash, logs string
+		var gasLimit, gasUsed uint64
+		stmt1, err := txWrapper.tx.Prepare("select contract_address, value, calldata, gas_limit, reason, status, gas_used, return_data, tx_hash, logs from governance_call_contract_detail where proposal_id = ?")
+		if err != nil {
+			panic(err)
+		}
+		defer stmt1.Close()
+		err = stmt1.QueryRow(pid).Scan(&contractAddr, &value, &data, &gasLimit, &reason, &status, &gasUsed, &returnData, &txHash, &logs)
+		switch {
+		case err == sql.ErrNoRows:
+			return nil
+		case err != nil:
+			panic(err)
+		}
+
+		ca := common.HexToAddress(contractAddr)
+
+		return &Proposal{
+			pid,
+			ptype,
+			&prp,
+			blockHeight,
+			expireTimestamp,
+			expireBlockHeight,
+			result,
+			resultMsg,
+			resultBlockHeight,
+			map[string]interface{}{
+				"contract_address": &ca,
+				"value":            value,
+				"data":             data,
+				"gas_limit":        gasLimit,
+				"reason":           reason,
+				"status":           status,
+				"gas_used":         gasUsed,
+				"return_data":      returnData,
+				"tx_hash":          txHash,
+				"logs":             logs,
+			},
+		}
+	case CHANGE_PARAMS_PROPOSAL:
+		var params, reason string
+		stmt1, err := txWrapper.tx.Prepare("select params, reason from governance_change_params_detail where proposal_id = ?")
+		if err != nil {
+			panic(err)
+		}
+		defer stmt1.Close()
+		err = stmt1.QueryRow(pid).Scan(&params, &reason)
+		switch {
+		case err == sql.ErrNoRows:
+			return nil
+		case err != nil:
+			panic(err)
+		}
+
+		var changes []utils.ParamChange
+		json.Unmarshal([]byte(params), &changes)
+
+		return &Proposal{
+			pid,
+			ptype,
+			&prp,
+			blockHeight,
+			expireTimestamp,
+			expireBlockHeight,
+			result,
+			resultMsg,
+			resultBlockHeight,
+			map[string]interface{}{
+				"params": changes,
+				"reason": reason,
+			},
+		}
+	case GRANT_PROPOSAL:
+		var fromAddr, toAddr, amount, reason, status, paidAmount string
+		var tranches, paidTranches uint64
+		var interval, nextBlockHeight int64
+		stmt1, err := txWrapper.tx.Prepare("select from_address, to_address, amount, tranches, interval, reason, status, paid_amount, paid_tranches, next_block_height from governance_grant_detail where proposal_id = ?")
+		if err != nil {
+			panic(err)
+		}
+		defer stmt1.Close()
+		err = stmt1.QueryRow(pid).Scan(&fromAddr, &toAddr, &amount, &tranches, &interval, &reason, &status, &paidAmount, &paidTranches, &nextBlockHeight)
+		switch {
+		case err == sql.ErrNoRows:
+			return nil
+		case err != nil:
+			panic(err)
+		}
+
+		fr := common.HexToAddress(fromAddr)
+		to := common.HexToAddress(toAddr)
+
+		return &Proposal{
+			pid,
+			ptype,
+			&prp,
+			blockHeight,
+			expireTimestamp,
+			expireBlockHeight,
+			result,
+			resultMsg,
+			resultBlockHeight,
+			map[string]interface{}{
+				"from":              &fr,
+				"to":                &to,
+				"amount":            amount,
+				"tranches":          tranches,
+				"interval":          interval,
+				"reason":            reason,
+				"status":            status,
+				"paid_amount":       paidAmount,
+				"paid_tranches":     paidTranches,
+				"next_block_height": nextBlockHeight,
+			},
+		}
+	case REVOKE_GRANT_PROPOSAL:
+		var grantId, reason string
+		stmt1, err := txWrapper.tx.Prepare("select grant_id, reason from governance_revoke_grant_detail where proposal_id = ?")
+		if err != nil {
+			panic(err)
+		}
+		defer stmt1.Close()
+		err = stmt1.QueryRow(pid).Scan(&grantId, &reason)
+		switch {
+		case err == sql.ErrNoRows:
+			return nil
+		case err != nil:
+			panic(err)
+		}
+
+		return &Proposal{
+			pid,
+			ptype,
+			&prp,
+			blockHeight,
+			expireTimestamp,
+			expireBlockHeight,
+			result,
+			resultMsg,
+			resultBlockHeight,
+			map[string]interface{}{
+				"grant_id": grantId,
+				"reason":   reason,
+			},
+		}
 	}
 
 	return nil
@@ -349,6 +549,7 @@ func UpdateProposalResult(pid, result, msg string, blockHeight int64) {
 
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
+	touchedProposals[pid] = true
 
 	stmt, err := txWrapper.tx.Prepare("update governance_proposal set result = ?, result_msg = ?, result_block_height = ?, hash = ? where id = ?")
 	if err != nil {
@@ -367,6 +568,50 @@ func UpdateProposalResult(pid, result, msg string, blockHeight int64) {
 	}
 }
 
+func UpdateGrantProgress(pid, status, paidAmount string, paidTranches uint64, nextBlockHeight int64) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+	touchedProposals[pid] = true
+
+	stmt, err := txWrapper.tx.Prepare("update governance_grant_detail set status = ?, paid_amount = ?, paid_tranches = ?, next_block_height = ? where proposal_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(status, paidAmount, paidTranches, nextBlockHeight, pid)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// GetDueGrantIds returns the IDs of the grants being paid with a tranche due at the block height
+func GetDueGrantIds(blockHeight int64) (ids []string) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	rows, err := txWrapper.tx.Query("select proposal_id from governance_grant_detail where status = 'paying' and next_block_height <= ? order by next_block_height, proposal_id", blockHeight)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			panic(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
+
 func UpdateDeployLibEniStatus(pid, status string) {
 	go func() {
 		db := getDb()
@@ -393,6 +638,7 @@ func UpdateDeployLibEniStatus(pid, status string) {
 func UpdateRetireProgramStatus(pid, status string) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
+	touchedProposals[pid] = true
 
 	stmt, err := txWrapper.tx.Prepare("update governance_retire_program_detail set status = ? where proposal_id = ?")
 	if err != nil {
@@ -407,6 +653,26 @@ func UpdateRetireProgramStatus(pid, status string) {
 	}
 }
 
+// UpdateCallContractResult records the outcome of the contract call of an approved proposal,
+// with the hash the call was executed under and the json encoded logs it emitted
+func UpdateCallContractResult(pid, status string, gasUsed uint64, returnData, txHash, logs string) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+	touchedProposals[pid] = true
+
+	stmt, err := txWrapper.tx.Prepare("update governance_call_contract_detail set status = ?, gas_used = ?, return_data = ?, tx_hash = ?, logs = ? where proposal_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(status, gasUsed, returnData, txHash, logs, pid)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
 func QueryProposals() (proposals []*Proposal) {
 	tx, err := getDb().Begin()
 	if err != nil {
@@ -414,11 +680,109 @@ func QueryProposals() (proposals []*Proposal) {
 	}
 	defer tx.Commit()
 
-	proposals = getProposals(tx)
+	proposals = getProposals(tx, "")
+	return
+}
+
+// QueryProposalsByFilter lists the proposals matching the filter, oldest first
+func QueryProposalsByFilter(filter *ProposalFilter) (proposals []*Proposal) {
+	var conds []string
+	var params []interface{}
+	if filter.Type != "" {
+		conds = append(conds, "p.type = ?")
+		params = append(params, filter.Type)
+	}
+	if strings.EqualFold(filter.Status, "pending") {
+		conds = append(conds, "p.result = ''")
+	} else if filter.Status != "" {
+		conds = append(conds, "lower(p.result) = lower(?)")
+		params = append(params, filter.Status)
+	}
+	if filter.Proposer != nil {
+		conds = append(conds, "p.proposer = ?")
+		params = append(params, filter.Proposer.String())
+	}
+	if filter.FromHeight > 0 {
+		conds = append(conds, "p.block_height >= ?")
+		params = append(params, filter.FromHeight)
+	}
+	if filter.ToHeight > 0 {
+		conds = append(conds, "p.block_height <= ?")
+		params = append(params, filter.ToHeight)
+	}
+
+	clause := ""
+	if len(conds) > 0 {
+		clause = "where " + strings.Join(conds, " and ")
+	}
+
+	// a negative limit means no limit in sqlite
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	offset := filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	clause += " order by p.block_height, p.id limit ? offset ?"
+	params = append(params, limit, offset)
+
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	proposals = getProposals(tx, clause, params...)
+	return
+}
+
+func QueryProposal(pid string) *Proposal {
+	tx, err := getDb().Begin()
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Commit()
+
+	proposals := getProposals(tx, "where p.id = ?", pid)
+	if len(proposals) == 0 {
+		return nil
+	}
+	return proposals[0]
+}
+
+func QueryVotes(pid string) (votes []*Vote) {
+	rows, err := getDb().Query("select voter, answer, block_height from governance_vote where proposal_id = ? order by block_height", pid)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var voter, answer string
+		var blockHeight int64
+		err = rows.Scan(&voter, &answer, &blockHeight)
+		if err != nil {
+			panic(err)
+		}
+
+		votes = append(votes, &Vote{
+			pid,
+			common.HexToAddress(voter),
+			blockHeight,
+			answer,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
 	return
 }
 
-func getProposals(tx *sql.Tx) (proposals []*Proposal) {
+func getProposals(tx *sql.Tx, clause string, params ...interface{}) (proposals []*Proposal) {
 	rows, err := tx.Query(`select p.id, p.type, p.proposer, p.block_height, p.expire_timestamp, p.expire_block_height, p.hash, p.result, p.result_msg, p.result_block_height,
 		case
 		when p.type = 'transfer_fund'
@@ -431,8 +795,16 @@ func getProposals(tx *sql.Tx) (proposals []*Proposal) {
 		then (select printf('%s-+-%s-+-%s-+-%s', retired_version, preserved_validators, reason, status) from governance_retire_program_detail where proposal_id = p.id)
 		when p.type = 'upgrade_program'
 		then (select printf('%s-+-%s-+-%s-+-%s-+-%s-+-%s', retired_version, name, version, fileurl, md5, reason) from governance_upgrade_program_detail where proposal_id = p.id)
+		when p.type = 'call_contract'
+		then (select printf('%s-+-%s-+-%s-+-%d-+-%s-+-%s-+-%d-+-%s-+-%s-+-%s', contract_address, value, calldata, gas_limit, reason, status, gas_used, return_data, tx_hash, logs) from governance_call_contract_detail where proposal_id = p.id)
+		when p.type = 'change_params'
+		then (select printf('%s-+-%s', params, reason) from governance_change_params_detail where proposal_id = p.id)
+		when p.type = 'grant'
+		then (select printf('%s-+-%s-+-%s-+-%d-+-%d-+-%s-+-%s-+-%s-+-%d-+-%d', from_address, to_address, amount, tranches, interval, reason, status, paid_amount, paid_tranches, next_block_height) from governance_grant_detail where proposal_id = p.id)
+		when p.type = 'revoke_grant'
+		then (select printf('%s-+-%s', grant_id, reason) from governance_revoke_grant_detail where proposal_id = p.id)
 		end as detail
-		from governance_proposal p`)
+		from governance_proposal p `+clause, params...)
 	if err != nil {
 		fmt.Println(err)
 		panic(err)
@@ -525,6 +897,65 @@ func getProposals(tx *sql.Tx) (proposals []*Proposal) {
 				"md5":             d[4],
 				"reason":          d[5],
 			}
+		case CALL_CONTRACT_PROPOSAL:
+			if len(d) != 10 {
+				continue
+			}
+			ca := common.HexToAddress(d[0])
+			gasLimit, _ := strconv.ParseUint(d[3], 10, 64)
+			gasUsed, _ := strconv.ParseUint(d[6], 10, 64)
+			pp.Detail = map[string]interface{}{
+				"contract_address": &ca,
+				"value":            d[1],
+				"data":             d[2],
+				"gas_limit":        gasLimit,
+				"reason":           d[4],
+				"status":           d[5],
+				"gas_used":         gasUsed,
+				"return_data":      d[7],
+				"tx_hash":          d[8],
+				"logs":             d[9],
+			}
+		case CHANGE_PARAMS_PROPOSAL:
+			if len(d) != 2 {
+				continue
+			}
+			var changes []utils.ParamChange
+			json.Unmarshal([]byte(d[0]), &changes)
+			pp.Detail = map[string]interface{}{
+				"params": changes,
+				"reason": d[1],
+			}
+		case GRANT_PROPOSAL:
+			if len(d) != 10 {
+				continue
+			}
+			fr := common.HexToAddress(d[0])
+			to := common.HexToAddress(d[1])
+			tranches, _ := strconv.ParseUint(d[3], 10, 64)
+			interval, _ := strconv.ParseInt(d[4], 10, 64)
+			paidTranches, _ := strconv.ParseUint(d[8], 10, 64)
+			nextBlockHeight, _ := strconv.ParseInt(d[9], 10, 64)
+			pp.Detail = map[string]interface{}{
+				"from":              &fr,
+				"to":                &to,
+				"amount":            d[2],
+				"tranches":          tranches,
+				"interval":          interval,
+				"reason":            d[5],
+				"status":            d[6],
+				"paid_amount":       d[7],
+				"paid_tranches":     paidTranches,
+				"next_block_height": nextBlockHeight,
+			}
+		case REVOKE_GRANT_PROPOSAL:
+			if len(d) != 2 {
+				continue
+			}
+			pp.Detail = map[string]interface{}{
+				"grant_id": d[0],
+				"reason":   d[1],
+			}
 		}
 
 		proposals = append(proposals, pp)
@@ -724,6 +1155,7 @@ func GetUpgradingProposal(version string) *Proposal {
 func SaveVote(vote *Vote) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
+	touchVote(vote)
 
 	stmt, err := txWrapper.tx.Prepare("insert into governance_vote(proposal_id, voter, block_height, answer, hash) values(?, ?, ?, ?, ?)")
 	if err != nil {
@@ -741,6 +1173,7 @@ func SaveVote(vote *Vote) {
 func UpdateVote(vote *Vote) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
+	touchVote(vote)
 
 	stmt, err := txWrapper.tx.Prepare("update governance_vote set answer = ?, hash = ? where proposal_id = ? and voter = ?")
 	if err != nil {
@@ -823,3 +1256,146 @@ func GetVotesByPid(pid string) (votes []*Vote) {
 
 	return
 }
+
+func SaveDeposit(deposit *Deposit) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into governance_proposal_deposit(proposal_id, depositor, amount, status, block_height, hash) values(?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(deposit.ProposalId, deposit.Depositor.String(), deposit.Amount, deposit.Status, deposit.BlockHeight, common.Bytes2Hex(deposit.Hash()))
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+func UpdateDeposit(deposit *Deposit) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("update governance_proposal_deposit set status = ?, block_height = ?, hash = ? where proposal_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(deposit.Status, deposit.BlockHeight, common.Bytes2Hex(deposit.Hash()), deposit.ProposalId)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+func GetDepositByPid(pid string) *Deposit {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("select depositor, amount, status, block_height from governance_proposal_deposit where proposal_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	var depositor, amount, status string
+	var blockHeight int64
+	err = stmt.QueryRow(pid).Scan(&depositor, &amount, &status, &blockHeight)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		panic(err)
+	}
+
+	return &Deposit{
+		pid,
+		common.HexToAddress(depositor),
+		amount,
+		status,
+		blockHeight,
+	}
+}
+
+// CountOpenProposals returns the number of undecided proposals of the proposer
+func CountOpenProposals(proposer common.Address) (count uint64) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("select count(*) from governance_proposal where proposer = ? and result = ''")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRow(proposer.String()).Scan(&count)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func SaveParamChangeLog(l *ParamChangeLog) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into governance_param_change_log(proposal_id, name, old_value, new_value, block_height, hash) values(?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(l.ProposalId, l.Name, l.OldValue, l.NewValue, l.BlockHeight, common.Bytes2Hex(l.Hash()))
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// ParamChangesQueryData encodes the param name of a param changes query, a zero
+// byte stands for all the params as the query data cannot be empty
+func ParamChangesQueryData(name string) []byte {
+	if name == "" {
+		return []byte{0}
+	}
+	return []byte(name)
+}
+
+// ParamChangesQueryName decodes the param name of a param changes query
+func ParamChangesQueryName(data []byte) string {
+	return strings.TrimRight(string(data), "\x00")
+}
+
+// QueryParamChangeLogs lists the changes of the param in the order they were applied,
+// the changes of all the params if the name is empty
+func QueryParamChangeLogs(name string) (logs []*ParamChangeLog) {
+	clause, params := "", []interface{}{}
+	if name != "" {
+		clause = "where name = ?"
+		params = append(params, name)
+	}
+
+	rows, err := getDb().Query("select proposal_id, name, old_value, new_value, block_height from governance_param_change_log "+clause+" order by block_height, rowid", params...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		l := &ParamChangeLog{}
+		err = rows.Scan(&l.ProposalId, &l.Name, &l.OldValue, &l.NewValue, &l.BlockHeight)
+		if err != nil {
+			panic(err)
+		}
+		logs = append(logs, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
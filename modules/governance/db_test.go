@@ -0,0 +1,20 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamChangesQueryData(t *testing.T) {
+	assert := assert.New(t)
+
+	// the store app rejects empty query data
+	data := ParamChangesQueryData("")
+	assert.NotEmpty(data)
+	assert.Equal("", ParamChangesQueryName(data))
+
+	data = ParamChangesQueryData("gas_price")
+	assert.Equal([]byte("gas_price"), data)
+	assert.Equal("gas_price", ParamChangesQueryName(data))
+}
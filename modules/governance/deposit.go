@@ -0,0 +1,92 @@
+package governance
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethState "github.com/ethereum/go-ethereum/core/state"
+
+	"github.com/second-state/devchain/commons"
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/utils"
+)
+
+// the deposit required to create a proposal, params loaded from an older state have none
+func proposalDeposit() *big.Int {
+	deposit, ok := new(big.Int).SetString(utils.GetParams().ProposalDeposit, 10)
+	if !ok || deposit.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return deposit
+}
+
+// checkProposer makes sure the proposer has not reached the cap of undecided proposals,
+// and can pay the gas fee, the deposit and any extra amount escrowed by the proposal
+func checkProposer(state *ethState.StateDB, proposer common.Address, gas uint64, extra *big.Int) error {
+	if max := utils.GetParams().MaxOpenProposalsPerProposer; max > 0 && CountOpenProposals(proposer) >= max {
+		return ErrTooManyOpenProposals()
+	}
+
+	gasFee, err := checkGasFee(state, proposer, gas)
+	if err != nil {
+		return err
+	}
+
+	total := big.NewInt(0).Add(gasFee, proposalDeposit())
+	if extra != nil {
+		total.Add(total, extra)
+	}
+	if state.GetBalance(proposer).Cmp(total) < 0 {
+		return ErrInsufficientBalance()
+	}
+	return nil
+}
+
+// escrowDeposit moves the deposit of a new proposal to the deposit escrow account, apart from
+// the hold account the fees are paid out of
+func escrowDeposit(state *ethState.StateDB, pid string, proposer common.Address, blockHeight int64) {
+	amount := proposalDeposit()
+	if amount.Sign() == 0 {
+		return
+	}
+
+	state.SubBalance(proposer, amount)
+	state.AddBalance(utils.DepositEscrowAccount, amount)
+
+	SaveDeposit(&Deposit{
+		pid,
+		proposer,
+		amount.String(),
+		"escrowed",
+		blockHeight,
+	})
+}
+
+// SettleDeposit refunds the deposit of a decided or canceled proposal, the deposit of a proposal
+// which expired without decision is sent to the treasury, or burned if there is none
+func SettleDeposit(pid, checkResult string, blockHeight int64) {
+	deposit := GetDepositByPid(pid)
+	if deposit == nil || deposit.Status != "escrowed" {
+		return
+	}
+
+	amount, ok := sdk.NewIntFromString(deposit.Amount)
+	if !ok {
+		return
+	}
+
+	switch checkResult {
+	case "approved", "rejected", "canceled":
+		commons.Transfer(utils.DepositEscrowAccount, deposit.Depositor, amount)
+		deposit.Status = "refunded"
+	default:
+		receiver := utils.MintAccount
+		if treasury := utils.GetParams().ProposalDepositTreasury; common.IsHexAddress(treasury) {
+			receiver = common.HexToAddress(treasury)
+		}
+		commons.Transfer(utils.DepositEscrowAccount, receiver, amount)
+		deposit.Status = "forfeited"
+	}
+	deposit.BlockHeight = blockHeight
+	UpdateDeposit(deposit)
+}
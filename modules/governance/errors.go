@@ -27,6 +27,14 @@ var (
 	errOngoingLibFound          = fmt.Errorf("One or more onging proposal with the same lib name")
 	errOngoingRetiringFound     = fmt.Errorf("Found unresolved or approved retiring proposal")
 	errExpirationTooClose       = fmt.Errorf("The proposal's expiration block height is too close")
+	errInvalidContract          = fmt.Errorf("No contract is deployed at the address")
+	errInvalidCallData          = fmt.Errorf("The call data is not valid hex")
+	errTooManyOpenProposals     = fmt.Errorf("Too many undecided proposals from the proposer")
+	errNotProposer              = fmt.Errorf("Only the proposer can cancel the proposal")
+	errDecidedProposal          = fmt.Errorf("The proposal has been decided")
+	errInvalidVoteAnswer        = fmt.Errorf("The answer must be Y (yes), N (no), A (abstain) or V (no with veto)")
+	errInvalidGrant             = fmt.Errorf("No grant is being paid with the ID")
+	errGasLimitTooHigh          = fmt.Errorf("The gas limit exceeds the call contract gas limit")
 )
 
 func ErrMissingSignature() error {
@@ -104,3 +112,35 @@ func ErrOngoingRetiringFound() error {
 func ErrExpirationTooClose() error {
 	return errors.WithCode(errExpirationTooClose, errors.CodeTypeBaseInvalidInput)
 }
+
+func ErrInvalidContract() error {
+	return errors.WithCode(errInvalidContract, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidCallData() error {
+	return errors.WithCode(errInvalidCallData, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrTooManyOpenProposals() error {
+	return errors.WithCode(errTooManyOpenProposals, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrNotProposer() error {
+	return errors.WithCode(errNotProposer, errors.CodeTypeUnauthorized)
+}
+
+func ErrDecidedProposal() error {
+	return errors.WithCode(errDecidedProposal, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidVoteAnswer() error {
+	return errors.WithCode(errInvalidVoteAnswer, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInvalidGrant() error {
+	return errors.WithCode(errInvalidGrant, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrGasLimitTooHigh() error {
+	return errors.WithCode(errGasLimitTooHigh, errors.CodeTypeBaseInvalidInput)
+}
@@ -0,0 +1,113 @@
+package governance
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/second-state/devchain/commons"
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/utils"
+)
+
+// StartGrant schedules the first tranche of an approved grant one interval after the approval
+func StartGrant(proposal *Proposal, blockHeight int64) {
+	interval := proposal.Detail["interval"].(int64)
+	UpdateGrantProgress(proposal.Id, "paying", "0", 0, blockHeight+interval)
+}
+
+// PayDueGrants pays the tranches due at the block height from the grant escrow account,
+// the last tranche of a grant pays what is left of its amount
+func PayDueGrants(blockHeight int64) {
+	for _, pid := range GetDueGrantIds(blockHeight) {
+		grant := GetProposalById(pid)
+		if grant == nil {
+			continue
+		}
+
+		amount, _ := sdk.NewIntFromString(grant.Detail["amount"].(string))
+		paid, _ := sdk.NewIntFromString(grant.Detail["paid_amount"].(string))
+		tranches := grant.Detail["tranches"].(uint64)
+		paidTranches := grant.Detail["paid_tranches"].(uint64)
+
+		tranche, last := grantTranche(amount, paid, tranches, paidTranches)
+		nextBlockHeight := grant.Detail["next_block_height"].(int64) + grant.Detail["interval"].(int64)
+		reactor := GrantReactor{
+			ProposalId:       pid,
+			Status:           "paying",
+			PaidAmount:       paid.Add(tranche).String(),
+			PaidTranches:     paidTranches + 1,
+			NextBlockHeight:  nextBlockHeight,
+			PrevPaidAmount:   paid.String(),
+			PrevPaidTranches: paidTranches,
+			RetryBlockHeight: nextBlockHeight,
+		}
+		if last {
+			reactor.Status = "paid"
+			reactor.NextBlockHeight = 0
+		}
+
+		if tranche.GT(sdk.ZeroInt) {
+			commons.TransferWithReactor(utils.GrantEscrowAccount, *grant.Detail["to"].(*common.Address), tranche, reactor)
+		} else {
+			reactor.React("success", "")
+		}
+	}
+}
+
+// grantTranche returns the amount of the next tranche of a grant and whether it is the last one.
+// The tranches are equal parts of the amount, the last one pays the rounding left by the others.
+func grantTranche(amount, paid sdk.Int, tranches, paidTranches uint64) (sdk.Int, bool) {
+	remainder := amount.Sub(paid)
+	if remainder.LTE(sdk.ZeroInt) {
+		return sdk.ZeroInt, true
+	}
+	if paidTranches+1 >= tranches {
+		return remainder, true
+	}
+
+	tranche := amount.Div(sdk.NewIntFromBigInt(new(big.Int).SetUint64(tranches)))
+	if tranche.GT(remainder) {
+		tranche = remainder
+	}
+	return tranche, false
+}
+
+// GrantReactor records the progress of a grant once its tranche is paid,
+// a tranche which can not be paid is tried again one interval later
+type GrantReactor struct {
+	ProposalId       string
+	Status           string
+	PaidAmount       string
+	PaidTranches     uint64
+	NextBlockHeight  int64
+	PrevPaidAmount   string
+	PrevPaidTranches uint64
+	RetryBlockHeight int64
+}
+
+func (gr GrantReactor) React(result, msg string) {
+	if result == "success" {
+		UpdateGrantProgress(gr.ProposalId, gr.Status, gr.PaidAmount, gr.PaidTranches, gr.NextBlockHeight)
+	} else {
+		UpdateGrantProgress(gr.ProposalId, "paying", gr.PrevPaidAmount, gr.PrevPaidTranches, gr.RetryBlockHeight)
+	}
+}
+
+// RevokeGrant stops paying the grant and refunds its unpaid remainder to the account
+// it was funded from. It returns the reason if the grant can not be revoked.
+func RevokeGrant(grantId string) string {
+	grant := GetProposalById(grantId)
+	if grant == nil || grant.Type != GRANT_PROPOSAL || grant.Detail["status"] != "paying" {
+		return "The grant is not being paid, nothing revoked"
+	}
+
+	amount, _ := sdk.NewIntFromString(grant.Detail["amount"].(string))
+	paid, _ := sdk.NewIntFromString(grant.Detail["paid_amount"].(string))
+	if remainder := amount.Sub(paid); remainder.GT(sdk.ZeroInt) {
+		commons.Transfer(utils.GrantEscrowAccount, *grant.Detail["from"].(*common.Address), remainder)
+	}
+
+	UpdateGrantProgress(grantId, "revoked", paid.String(), grant.Detail["paid_tranches"].(uint64), 0)
+	return ""
+}
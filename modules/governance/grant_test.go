@@ -0,0 +1,50 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/second-state/devchain/sdk"
+)
+
+func TestGrantTranche(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		amount, paid int64
+		tranches     uint64
+		paidTranches uint64
+		tranche      int64
+		last         bool
+	}{
+		{100, 0, 4, 0, 25, false},
+		{100, 75, 4, 3, 25, true},
+		{100, 0, 1, 0, 100, true},
+		// the last tranche pays the rounding
+		{10, 0, 3, 0, 3, false},
+		{10, 6, 3, 2, 4, true},
+		// one unit per tranche
+		{3, 0, 3, 0, 1, false},
+		{3, 2, 3, 2, 1, true},
+		// a tranche never pays more than what is left
+		{10, 9, 3, 1, 1, false},
+		{10, 10, 3, 1, 0, true},
+		{10, 0, 3, 5, 10, true},
+	}
+
+	for _, c := range cases {
+		tranche, last := grantTranche(sdk.NewInt(c.amount), sdk.NewInt(c.paid), c.tranches, c.paidTranches)
+		assert.Equal(c.tranche, tranche.Int64(), "%+v", c)
+		assert.Equal(c.last, last, "%+v", c)
+	}
+
+	// paying every tranche pays the whole amount
+	amount, paid := sdk.NewInt(1000), sdk.ZeroInt
+	for i := uint64(0); i < 7; i++ {
+		tranche, last := grantTranche(amount, paid, 7, i)
+		paid = paid.Add(tranche)
+		assert.Equal(i == 6, last)
+	}
+	assert.Equal(amount.Int64(), paid.Int64())
+}
@@ -1,6 +1,7 @@
 package governance
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"math/big"
@@ -99,9 +100,103 @@ func CheckTx(ctx types.Context, store state.SimpleDB,
 		if err != nil {
 			return sdk.NewCheck(0, ""), err
 		}
+
+		// Check the open proposals and the deposit of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().TransferFundProposalGas, nil); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
+		// app_state.SubBalance(*txInner.From, amount)
+		// app_state.SubBalance(sender, gasFee.Int)
+
+	case TxGrantPropose:
+		validators := stake.GetCandidates().Validators()
+		if validators == nil || validators.Len() == 0 {
+			return sdk.NewCheck(0, ""), ErrInvalidValidator()
+		}
+		for i, v := range validators {
+			if v.OwnerAddress == sender.String() {
+				break
+			}
+			if i+1 == len(validators) {
+				return sdk.NewCheck(0, ""), ErrInvalidValidator()
+			}
+		}
+
+		amount := big.NewInt(0)
+		amount.SetString(txInner.Amount, 10)
+		if amount.Cmp(big.NewInt(0)) <= 0 {
+			return sdk.NewCheck(0, ""), ErrInvalidParameter()
+		}
+
+		balance, err := commons.GetBalance(app_state, *txInner.From)
+		if err != nil {
+			return sdk.NewCheck(0, ""), ErrInvalidParameter()
+		}
+
+		if balance.Cmp(amount) < 0 {
+			return sdk.NewCheck(0, ""), ErrInsufficientBalance()
+		}
+
+		if txInner.ExpireTimestamp != nil && txInner.ExpireBlockHeight != nil {
+			return sdk.NewCheck(0, ""), ErrExceedsExpiration()
+		}
+
+		if txInner.ExpireTimestamp != nil && ctx.BlockTime() > *txInner.ExpireTimestamp {
+			return sdk.NewCheck(0, ""), ErrInvalidExpireTimestamp()
+		}
+
+		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
+			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
+		}
+
+		// Transfer gasFee
+		_, err = checkGasFee(app_state, sender, utils.GetParams().TransferFundProposalGas)
+		if err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
+
+		// Check the open proposals and the deposit of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().TransferFundProposalGas, nil); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
 		// app_state.SubBalance(*txInner.From, amount)
 		// app_state.SubBalance(sender, gasFee.Int)
 
+	case TxRevokeGrantPropose:
+		validators := stake.GetCandidates().Validators()
+		if validators == nil || validators.Len() == 0 {
+			return sdk.NewCheck(0, ""), ErrInvalidValidator()
+		}
+		for i, v := range validators {
+			if v.OwnerAddress == sender.String() {
+				break
+			}
+			if i+1 == len(validators) {
+				return sdk.NewCheck(0, ""), ErrInvalidValidator()
+			}
+		}
+
+		grant := GetProposalById(txInner.GrantId)
+		if grant == nil || grant.Type != GRANT_PROPOSAL || grant.Detail["status"] != "paying" {
+			return sdk.NewCheck(0, ""), ErrInvalidGrant()
+		}
+
+		if txInner.ExpireTimestamp != nil && txInner.ExpireBlockHeight != nil {
+			return sdk.NewCheck(0, ""), ErrExceedsExpiration()
+		}
+
+		if txInner.ExpireTimestamp != nil && ctx.BlockTime() > *txInner.ExpireTimestamp {
+			return sdk.NewCheck(0, ""), ErrInvalidExpireTimestamp()
+		}
+
+		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
+			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
+		}
+
+		// Check the open proposals, and the gasFee and deposit of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().TransferFundProposalGas, nil); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
 	case TxChangeParamPropose:
 		validators := stake.GetCandidates().Validators()
 		if validators == nil || validators.Len() == 0 {
@@ -128,7 +223,7 @@ func CheckTx(ctx types.Context, store state.SimpleDB,
 			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
 		}
 
-		if !utils.CheckParamType(txInner.Name, txInner.Value) {
+		if !utils.CheckParamValue(txInner.Name, txInner.Value) {
 			return sdk.NewCheck(0, ""), ErrInvalidParameter()
 		}
 
@@ -137,6 +232,52 @@ func CheckTx(ctx types.Context, store state.SimpleDB,
 		if err != nil {
 			return sdk.NewCheck(0, ""), err
 		}
+
+		// Check the open proposals and the deposit of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().ChangeParamsProposalGas, nil); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
+		// app_state.SubBalance(sender, gasFee.Int)
+	case TxChangeParamsPropose:
+		validators := stake.GetCandidates().Validators()
+		if validators == nil || validators.Len() == 0 {
+			return sdk.NewCheck(0, ""), ErrInvalidValidator()
+		}
+		for i, v := range validators {
+			if v.OwnerAddress == sender.String() {
+				break
+			}
+			if i+1 == len(validators) {
+				return sdk.NewCheck(0, ""), ErrInvalidValidator()
+			}
+		}
+
+		if txInner.ExpireTimestamp != nil && txInner.ExpireBlockHeight != nil {
+			return sdk.NewCheck(0, ""), ErrExceedsExpiration()
+		}
+
+		if txInner.ExpireTimestamp != nil && ctx.BlockTime() > *txInner.ExpireTimestamp {
+			return sdk.NewCheck(0, ""), ErrInvalidExpireTimestamp()
+		}
+
+		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
+			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
+		}
+
+		if !utils.CheckParamValues(txInner.Params) {
+			return sdk.NewCheck(0, ""), ErrInvalidParameter()
+		}
+
+		// Transfer gasFee
+		_, err = checkGasFee(app_state, sender, utils.GetParams().ChangeParamsProposalGas)
+		if err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
+
+		// Check the open proposals and the deposit of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().ChangeParamsProposalGas, nil); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
 		// app_state.SubBalance(sender, gasFee.Int)
 	case TxDeployLibEniPropose:
 		validators := stake.GetCandidates().Validators()
@@ -205,6 +346,11 @@ func CheckTx(ctx types.Context, store state.SimpleDB,
 		if err != nil {
 			return sdk.NewCheck(0, ""), err
 		}
+
+		// Check the open proposals and the deposit of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().DeployLibEniProposalGas, nil); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
 		// app_state.SubBalance(sender, gasFee.Int)
 	case TxRetireProgramPropose:
 		validators := stake.GetCandidates().Validators()
@@ -238,6 +384,11 @@ func CheckTx(ctx types.Context, store state.SimpleDB,
 		if err != nil {
 			return sdk.NewCheck(0, ""), err
 		}
+
+		// Check the open proposals and the deposit of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().RetireProgramProposalGas, nil); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
 		// app_state.SubBalance(sender, gasFee.Int)
 	case TxUpgradeProgramPropose:
 		validators := stake.GetCandidates().Validators()
@@ -282,7 +433,81 @@ func CheckTx(ctx types.Context, store state.SimpleDB,
 		if err != nil {
 			return sdk.NewCheck(0, ""), err
 		}
+
+		// Check the open proposals and the deposit of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().UpgradeProgramProposalGas, nil); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
 		// app_state.SubBalance(sender, gasFee.Int)
+	case TxCallContractPropose:
+		validators := stake.GetCandidates().Validators()
+		if validators == nil || validators.Len() == 0 {
+			return sdk.NewCheck(0, ""), ErrInvalidValidator()
+		}
+		for i, v := range validators {
+			if v.OwnerAddress == sender.String() {
+				break
+			}
+			if i+1 == len(validators) {
+				return sdk.NewCheck(0, ""), ErrInvalidValidator()
+			}
+		}
+
+		if txInner.ContractAddress == nil || app_state.GetCodeSize(*txInner.ContractAddress) == 0 {
+			return sdk.NewCheck(0, ""), ErrInvalidContract()
+		}
+
+		if _, err := DecodeCallData(txInner.Data); err != nil {
+			return sdk.NewCheck(0, ""), ErrInvalidCallData()
+		}
+
+		// the call is paid from the block gas, no more than the gas limit param can be asked for
+		if txInner.GasLimit > utils.GetParams().CallContractGasLimit {
+			return sdk.NewCheck(0, ""), ErrGasLimitTooHigh()
+		}
+
+		// the value is escrowed from the proposer until the proposal is resolved
+		value := big.NewInt(0)
+		if txInner.Value != "" {
+			if _, ok := value.SetString(txInner.Value, 10); !ok || value.Sign() < 0 {
+				return sdk.NewCheck(0, ""), ErrInvalidParameter()
+			}
+		}
+
+		if txInner.ExpireTimestamp != nil && txInner.ExpireBlockHeight != nil {
+			return sdk.NewCheck(0, ""), ErrExceedsExpiration()
+		}
+
+		if txInner.ExpireTimestamp != nil && ctx.BlockTime() > *txInner.ExpireTimestamp {
+			return sdk.NewCheck(0, ""), ErrInvalidExpireTimestamp()
+		}
+
+		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight {
+			return sdk.NewCheck(0, ""), ErrInvalidExpireBlockHeight()
+		}
+
+		// the call is executed in the block after the approval, which must come before the expiration
+		if txInner.ExpireBlockHeight != nil && ctx.BlockHeight() >= *txInner.ExpireBlockHeight - 2 {
+			return sdk.NewCheck(0, ""), ErrExpirationTooClose()
+		}
+
+		// Check the open proposals, and the gasFee, deposit and value of the proposer
+		if err = checkProposer(app_state, sender, utils.GetParams().CallContractProposalGas, value); err != nil {
+			return sdk.NewCheck(0, ""), err
+		}
+	case TxCancelProposal:
+		proposal := GetProposalById(txInner.ProposalId)
+		if proposal == nil {
+			return sdk.NewCheck(0, ""), ErrInvalidParameter()
+		}
+
+		if *proposal.Proposer != sender {
+			return sdk.NewCheck(0, ""), ErrNotProposer()
+		}
+
+		if proposal.Result != "" || proposal.ResultBlockHeight != 0 {
+			return sdk.NewCheck(0, ""), ErrDecidedProposal()
+		}
 	case TxVote:
 		validators := stake.GetCandidates().Validators()
 		if validators == nil || validators.Len() == 0 {
@@ -366,6 +591,64 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 		app_state.AddBalance(utils.GovHoldAccount, amount)
 
 		SaveProposal(pp)
+		escrowDeposit(app_state, pp.Id, sender, ctx.BlockHeight())
+
+		// Check gasFee  -- start
+		// get the sender
+		sender, err := getTxSender(ctx)
+		if err != nil {
+			return res, err
+		}
+		params := utils.GetParams()
+		gasUsed := params.TransferFundProposalGas
+
+		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
+			return res, err
+		} else {
+			res.GasFee = gasFee
+			res.GasUsed = int64(gasUsed)
+			// transfer gasFee
+			app_state.SubBalance(sender, gasFee)
+			app_state.AddBalance(utils.HoldAccount, gasFee)
+		}
+		// Check gasFee  -- end
+
+		utils.PendingProposal.Add(pp.Id, pp.ExpireTimestamp, pp.ExpireBlockHeight)
+
+		res.Data = hash
+
+	case TxGrantPropose:
+		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
+		var expireTimestamp int64
+		if txInner.ExpireTimestamp != nil {
+			expireTimestamp = *txInner.ExpireTimestamp
+			expireBlockHeight = 0
+		} else if txInner.ExpireBlockHeight != nil {
+			expireBlockHeight = *txInner.ExpireBlockHeight
+		}
+		hashJson, _ := json.Marshal(hash)
+		pp := NewGrantProposal(
+			string(hashJson[1:len(hashJson)-1]),
+			&sender,
+			ctx.BlockHeight(),
+			txInner.From,
+			txInner.To,
+			txInner.Amount,
+			txInner.Tranches,
+			txInner.Interval,
+			txInner.Reason,
+			expireTimestamp,
+			expireBlockHeight,
+		)
+
+		amount := big.NewInt(0)
+		amount.SetString(txInner.Amount, 10)
+
+		app_state.SubBalance(*pp.Detail["from"].(*common.Address), amount)
+		app_state.AddBalance(utils.GrantEscrowAccount, amount)
+
+		SaveProposal(pp)
+		escrowDeposit(app_state, pp.Id, sender, ctx.BlockHeight())
 
 		// Check gasFee  -- start
 		// get the sender
@@ -391,6 +674,52 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 
 		res.Data = hash
 
+	case TxRevokeGrantPropose:
+		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
+		var expireTimestamp int64
+		if txInner.ExpireTimestamp != nil {
+			expireTimestamp = *txInner.ExpireTimestamp
+			expireBlockHeight = 0
+		} else if txInner.ExpireBlockHeight != nil {
+			expireBlockHeight = *txInner.ExpireBlockHeight
+		}
+		hashJson, _ := json.Marshal(hash)
+		cp := NewRevokeGrantProposal(
+			string(hashJson[1:len(hashJson)-1]),
+			&sender,
+			ctx.BlockHeight(),
+			txInner.GrantId,
+			txInner.Reason,
+			expireTimestamp,
+			expireBlockHeight,
+		)
+		SaveProposal(cp)
+		escrowDeposit(app_state, cp.Id, sender, ctx.BlockHeight())
+
+		// Check gasFee  -- start
+		// get the sender
+		sender, err := getTxSender(ctx)
+		if err != nil {
+			return res, err
+		}
+		params := utils.GetParams()
+		gasUsed := params.TransferFundProposalGas
+
+		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
+			return res, err
+		} else {
+			res.GasFee = gasFee
+			res.GasUsed = int64(gasUsed)
+			// transfer gasFee
+			app_state.SubBalance(sender, gasFee)
+			app_state.AddBalance(utils.HoldAccount, gasFee)
+		}
+		// Check gasFee  -- end
+
+		utils.PendingProposal.Add(cp.Id, cp.ExpireTimestamp, cp.ExpireBlockHeight)
+
+		res.Data = hash
+
 	case TxChangeParamPropose:
 		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
 		var expireTimestamp int64
@@ -412,6 +741,53 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 			expireBlockHeight,
 		)
 		SaveProposal(cp)
+		escrowDeposit(app_state, cp.Id, sender, ctx.BlockHeight())
+
+		// Check gasFee  -- start
+		// get the sender
+		sender, err := getTxSender(ctx)
+		if err != nil {
+			return res, err
+		}
+		params := utils.GetParams()
+		gasUsed := params.ChangeParamsProposalGas
+
+		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
+			return res, err
+		} else {
+			res.GasFee = gasFee
+			res.GasUsed = int64(gasUsed)
+			// transfer gasFee
+			app_state.SubBalance(sender, gasFee)
+			app_state.AddBalance(utils.HoldAccount, gasFee)
+		}
+		// Check gasFee  -- end
+
+		utils.PendingProposal.Add(cp.Id, cp.ExpireTimestamp, cp.ExpireBlockHeight)
+
+		res.Data = hash
+
+	case TxChangeParamsPropose:
+		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
+		var expireTimestamp int64
+		if txInner.ExpireTimestamp != nil {
+			expireTimestamp = *txInner.ExpireTimestamp
+			expireBlockHeight = 0
+		} else if txInner.ExpireBlockHeight != nil {
+			expireBlockHeight = *txInner.ExpireBlockHeight
+		}
+		hashJson, _ := json.Marshal(hash)
+		cp := NewChangeParamsProposal(
+			string(hashJson[1:len(hashJson)-1]),
+			&sender,
+			ctx.BlockHeight(),
+			txInner.Params,
+			txInner.Reason,
+			expireTimestamp,
+			expireBlockHeight,
+		)
+		SaveProposal(cp)
+		escrowDeposit(app_state, cp.Id, sender, ctx.BlockHeight())
 
 		// Check gasFee  -- start
 		// get the sender
@@ -461,6 +837,7 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 			expireBlockHeight,
 		)
 		SaveProposal(dp)
+		escrowDeposit(app_state, dp.Id, sender, ctx.BlockHeight())
 
 		// Check gasFee  -- start
 		// get the sender
@@ -504,6 +881,7 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 			expireBlockHeight,
 		)
 		SaveProposal(cp)
+		escrowDeposit(app_state, cp.Id, sender, ctx.BlockHeight())
 
 		// Check gasFee  -- start
 		// get the sender
@@ -548,6 +926,7 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 			expireBlockHeight,
 		)
 		SaveProposal(cp)
+		escrowDeposit(app_state, cp.Id, sender, ctx.BlockHeight())
 
 		// Check gasFee  -- start
 		// get the sender
@@ -574,6 +953,95 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 
 		DownloadProgramCmd(cp)
 
+	case TxCallContractPropose:
+		expireBlockHeight := ctx.BlockHeight() + int64(utils.GetParams().ProposalExpirePeriod)
+		var expireTimestamp int64
+		if txInner.ExpireTimestamp != nil {
+			expireTimestamp = *txInner.ExpireTimestamp
+			expireBlockHeight = 0
+		} else if txInner.ExpireBlockHeight != nil {
+			expireBlockHeight = *txInner.ExpireBlockHeight
+		}
+		value := "0"
+		if txInner.Value != "" {
+			value = txInner.Value
+		}
+		gasLimit := txInner.GasLimit
+		if gasLimit == 0 {
+			gasLimit = utils.GetParams().CallContractGasLimit
+		}
+		hashJson, _ := json.Marshal(hash)
+		cp := NewCallContractProposal(
+			string(hashJson[1:len(hashJson)-1]),
+			&sender,
+			ctx.BlockHeight(),
+			txInner.ContractAddress,
+			value,
+			txInner.Data,
+			gasLimit,
+			txInner.Reason,
+			expireTimestamp,
+			expireBlockHeight,
+		)
+
+		amount := big.NewInt(0)
+		amount.SetString(value, 10)
+
+		app_state.SubBalance(sender, amount)
+		app_state.AddBalance(utils.GovHoldAccount, amount)
+
+		SaveProposal(cp)
+		escrowDeposit(app_state, cp.Id, sender, ctx.BlockHeight())
+
+		// Check gasFee  -- start
+		params := utils.GetParams()
+		gasUsed := params.CallContractProposalGas
+
+		if gasFee, err := checkGasFee(app_state, sender, gasUsed); err != nil {
+			return res, err
+		} else {
+			res.GasFee = gasFee
+			res.GasUsed = int64(gasUsed)
+			// transfer gasFee
+			app_state.SubBalance(sender, gasFee)
+			app_state.AddBalance(utils.HoldAccount, gasFee)
+		}
+		// Check gasFee  -- end
+
+		utils.PendingProposal.Add(cp.Id, cp.ExpireTimestamp, cp.ExpireBlockHeight)
+
+		res.Data = hash
+
+	case TxCancelProposal:
+		proposal := GetProposalById(txInner.ProposalId)
+
+		// refund the amount escrowed by the proposal
+		switch proposal.Type {
+		case TRANSFER_FUND_PROPOSAL:
+			amount := big.NewInt(0)
+			amount.SetString(proposal.Detail["amount"].(string), 10)
+			app_state.SubBalance(utils.GovHoldAccount, amount)
+			app_state.AddBalance(*proposal.Detail["from"].(*common.Address), amount)
+		case GRANT_PROPOSAL:
+			amount := big.NewInt(0)
+			amount.SetString(proposal.Detail["amount"].(string), 10)
+			app_state.SubBalance(utils.GrantEscrowAccount, amount)
+			app_state.AddBalance(*proposal.Detail["from"].(*common.Address), amount)
+		case CALL_CONTRACT_PROPOSAL:
+			amount := big.NewInt(0)
+			amount.SetString(proposal.Detail["value"].(string), 10)
+			app_state.SubBalance(utils.GovHoldAccount, amount)
+			app_state.AddBalance(*proposal.Proposer, amount)
+		case DEPLOY_LIBENI_PROPOSAL:
+			if proposal.Detail["status"] != "ready" {
+				CancelDownload(proposal, false)
+			}
+		}
+		SettleDeposit(proposal.Id, "canceled", ctx.BlockHeight())
+
+		utils.PendingProposal.Del(proposal.Id)
+		UpdateProposalResult(proposal.Id, "Canceled", "", ctx.BlockHeight())
+
 	case TxVote:
 		var vote *Vote
 		if vote = GetVoteByPidAndVoter(txInner.ProposalId, sender.String()); vote != nil {
@@ -617,11 +1085,36 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 			if checkResult == "approved" || checkResult == "rejected" {
 				utils.PendingProposal.Del(proposal.Id)
 			}
-		case CHANGE_PARAM_PROPOSAL:
+		case GRANT_PROPOSAL:
 			switch checkResult {
 			case "approved":
-				utils.SetParam(proposal.Detail["name"].(string), proposal.Detail["value"].(string))
+				// the tranches are paid when the blocks are committed
+				StartGrant(proposal, ctx.BlockHeight())
 				UpdateProposalResult(proposal.Id, "Approved", "", ctx.BlockHeight())
+			case "rejected":
+				amount := big.NewInt(0)
+				amount.SetString(proposal.Detail["amount"].(string), 10)
+				app_state.SubBalance(utils.GrantEscrowAccount, amount)
+				app_state.AddBalance(*proposal.Detail["from"].(*common.Address), amount)
+				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
+			}
+			if checkResult == "approved" || checkResult == "rejected" {
+				utils.PendingProposal.Del(proposal.Id)
+			}
+		case REVOKE_GRANT_PROPOSAL:
+			switch checkResult {
+			case "approved":
+				UpdateProposalResult(proposal.Id, "Approved", RevokeGrant(proposal.Detail["grant_id"].(string)), ctx.BlockHeight())
+			case "rejected":
+				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
+			}
+			if checkResult == "approved" || checkResult == "rejected" {
+				utils.PendingProposal.Del(proposal.Id)
+			}
+		case CHANGE_PARAM_PROPOSAL, CHANGE_PARAMS_PROPOSAL:
+			switch checkResult {
+			case "approved":
+				UpdateProposalResult(proposal.Id, "Approved", ApplyParamChanges(proposal, ctx.BlockHeight()), ctx.BlockHeight())
 			case "rejected":
 				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
 			}
@@ -655,60 +1148,74 @@ func DeliverTx(ctx types.Context, store state.SimpleDB,
 				utils.PendingProposal.Del(proposal.Id)
 				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
 			}
+		case CALL_CONTRACT_PROPOSAL:
+			switch checkResult {
+			case "approved":
+				// the contract is called when the block is committed
+				UpdateProposalResult(proposal.Id, "Approved", "", ctx.BlockHeight())
+				utils.PendingProposal.Del(proposal.Id)
+				utils.PendingProposal.Add(proposal.Id, 0, ctx.BlockHeight())
+			case "rejected":
+				amount := big.NewInt(0)
+				amount.SetString(proposal.Detail["value"].(string), 10)
+				app_state.SubBalance(utils.GovHoldAccount, amount)
+				app_state.AddBalance(*proposal.Proposer, amount)
+				utils.PendingProposal.Del(proposal.Id)
+				UpdateProposalResult(proposal.Id, "Rejected", "", ctx.BlockHeight())
+			}
+		}
+
+		if checkResult == "approved" || checkResult == "rejected" {
+			SettleDeposit(proposal.Id, checkResult, ctx.BlockHeight())
 		}
 	}
 
 	return
 }
 
+// CheckProposal decides the proposal from the tally of its votes. The voter is the
+// validator who just voted, nil when the proposal reaches its expiration.
 func CheckProposal(pid string, voter *common.Address) string {
-	votes := GetVotesByPid(pid)
-	validators := stake.GetCandidates().Validators()
-
-	if validators == nil || validators.Len() == 0 {
-		return "no validator"
+	tally := Tally(pid, voter == nil, nil)
+	if tally == nil {
+		return "not determined"
 	}
 
-	approvedPower := big.NewInt(0)
-	rejectedPower := big.NewInt(0)
-	allPower := big.NewInt(0)
-	voterPower := big.NewInt(0)
-	for _, va := range validators {
-		for _, vo := range votes {
-			// should check voter is still valid validator first
-			if vo.Voter.String() == va.OwnerAddress {
-				if strings.Compare(vo.Answer, "Y") == 0 {
-					approvedPower.Add(approvedPower, big.NewInt(va.VotingPower))
-				}
-				if strings.Compare(vo.Answer, "N") == 0 {
-					rejectedPower.Add(rejectedPower, big.NewInt(va.VotingPower))
-				}
-			}
-		}
-		if voter != nil && voter.String() == va.OwnerAddress {
-			voterPower = big.NewInt(va.VotingPower)
+	if voter != nil && (tally.Result == "approved" || tally.Result == "rejected") {
+		// To avoid repeated commit, let's recheck without the vote of the voter
+		if before := Tally(pid, false, voter); before != nil && before.Result == tally.Result {
+			return "not determined"
 		}
-		allPower.Add(allPower, big.NewInt(va.VotingPower))
 	}
+	return tally.Result
+}
 
-	allPower.Mul(allPower, big.NewInt(2))
-	three := big.NewInt(3)
-	voterPower.Mul(voterPower, three)
-	approvedPower.Mul(approvedPower, three)
-	rejectedPower.Mul(rejectedPower, three)
+// ApplyParamChanges sets the params of an approved change_param or change_params proposal,
+// and records the changes in the param changelog. The values are checked again, if any of
+// them is out of bounds none is set and the returned message tells why.
+func ApplyParamChanges(proposal *Proposal, blockHeight int64) string {
+	var changes []utils.ParamChange
+	switch proposal.Type {
+	case CHANGE_PARAM_PROPOSAL:
+		changes = []utils.ParamChange{{proposal.Detail["name"].(string), proposal.Detail["value"].(string)}}
+	case CHANGE_PARAMS_PROPOSAL:
+		changes = proposal.Detail["params"].([]utils.ParamChange)
+	}
 
-	if approvedPower.Cmp(allPower) >= 0 {
-		// To avoid repeated commit, let's recheck with count of voters - voter
-		if voter == nil || approvedPower.Sub(approvedPower, voterPower).Cmp(allPower) < 0 {
-			return "approved"
-		}
-	} else if rejectedPower.Cmp(allPower) >= 0 {
-		// To avoid repeated commit, let's recheck with count of voters - voter
-		if voter == nil || rejectedPower.Sub(rejectedPower, voterPower).Cmp(allPower) < 0 {
-			return "rejected"
-		}
+	logs := make([]*ParamChangeLog, 0, len(changes))
+	for _, c := range changes {
+		old, _ := utils.GetParamValue(c.Name)
+		logs = append(logs, &ParamChangeLog{proposal.Id, c.Name, old, c.Value, blockHeight})
 	}
-	return "not determined"
+
+	if !utils.SetParamValues(changes) {
+		return "Invalid parameter value, no parameter changed"
+	}
+
+	for _, l := range logs {
+		SaveParamChangeLog(l)
+	}
+	return ""
 }
 
 type ProposalReactor struct {
@@ -728,6 +1235,11 @@ func (pr ProposalReactor) React(result, msg string) {
 	UpdateProposalResult(pr.ProposalId, result, msg, pr.BlockHeight)
 }
 
+// DecodeCallData decodes the hex encoded call data of a contract call proposal
+func DecodeCallData(data string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(data, "0x"), "0X"))
+}
+
 // get the sender from the ctx and ensure it matches the tx pubkey
 func getTxSender(ctx types.Context) (sender common.Address, err error) {
 	senders := ctx.GetSigners()
@@ -0,0 +1,108 @@
+package governance
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/second-state/devchain/sdk/state"
+	"github.com/second-state/devchain/utils"
+)
+
+// the proposals and votes written in the block being delivered,
+// they are copied into the merkle store at commit
+var (
+	touchedProposals = make(map[string]bool)
+	touchedVotes     = make(map[string]*Vote)
+)
+
+// ProposalKey is the store key of the proposal record
+func ProposalKey(pid string) []byte {
+	return append(append([]byte{}, utils.ProposalPrefix...), []byte(pid)...)
+}
+
+// VoteKey is the store key of the vote record, the votes on a proposal share the
+// prefix of the proposal id
+func VoteKey(pid string, voter common.Address) []byte {
+	return append(VotesPrefix(pid), voter.Bytes()...)
+}
+
+// VotesPrefix is the prefix of the store keys of the votes on the proposal
+func VotesPrefix(pid string) []byte {
+	return append(append([]byte{}, utils.VotePrefix...), []byte(pid+"/")...)
+}
+
+func touchVote(vote *Vote) {
+	touchedVotes[string(VoteKey(vote.ProposalId, vote.Voter))] = vote
+}
+
+// SyncStore copies the proposals and votes written in the block into the store.
+// The keys are sorted as the tree depends on the order of the writes.
+func SyncStore(store state.SimpleDB) {
+	pids := make([]string, 0, len(touchedProposals))
+	for pid := range touchedProposals {
+		pids = append(pids, pid)
+	}
+	sort.Strings(pids)
+	for _, pid := range pids {
+		if p := GetProposalById(pid); p != nil {
+			setProposalRecord(store, p)
+		}
+	}
+
+	keys := make([]string, 0, len(touchedVotes))
+	for key := range touchedVotes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		v := touchedVotes[key]
+		if vote := GetVoteByPidAndVoter(v.ProposalId, v.Voter.String()); vote != nil {
+			setRecord(store, []byte(key), vote)
+		}
+	}
+
+	ResetStoreChanges()
+}
+
+// ResetStoreChanges forgets the records written in a block which is not committed
+func ResetStoreChanges() {
+	touchedProposals = make(map[string]bool)
+	touchedVotes = make(map[string]*Vote)
+}
+
+// MigrateStore copies all the proposals and votes of the database into the store
+func MigrateStore(store state.SimpleDB) {
+	txWrapper := getSqlTxWrapper()
+	proposals := getProposals(txWrapper.tx, "")
+	txWrapper.Commit()
+
+	sort.Slice(proposals, func(i, j int) bool { return proposals[i].Id < proposals[j].Id })
+	for _, p := range proposals {
+		setProposalRecord(store, p)
+
+		votes := GetVotesByPid(p.Id)
+		sort.Slice(votes, func(i, j int) bool { return votes[i].Voter.Hex() < votes[j].Voter.Hex() })
+		for _, v := range votes {
+			setRecord(store, VoteKey(v.ProposalId, v.Voter), v)
+		}
+	}
+}
+
+// the deployment status of a library depends on the download by each node,
+// it is left out of the record as it is of the proposal hash
+func setProposalRecord(store state.SimpleDB, p *Proposal) {
+	if p.Type == DEPLOY_LIBENI_PROPOSAL && p.Detail != nil {
+		delete(p.Detail, "status")
+	}
+	setRecord(store, ProposalKey(p.Id), p)
+}
+
+func setRecord(store state.SimpleDB, key []byte, record interface{}) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		panic(err)
+	}
+	store.Set(key, b)
+}
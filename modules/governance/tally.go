@@ -0,0 +1,163 @@
+package governance
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/second-state/devchain/modules/stake"
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/utils"
+)
+
+// TallyResult is the breakdown of the voting power of the validators on a proposal
+type TallyResult struct {
+	Yes               int64   `json:"yes"`
+	No                int64   `json:"no"`
+	NoWithVeto        int64   `json:"no_with_veto"`
+	Abstain           int64   `json:"abstain"`
+	NonVoting         int64   `json:"non_voting"`
+	Total             int64   `json:"total"`
+	YesPercent        string  `json:"yes_percent"`
+	NoPercent         string  `json:"no_percent"`
+	NoWithVetoPercent string  `json:"no_with_veto_percent"`
+	AbstainPercent    string  `json:"abstain_percent"`
+	NonVotingPercent  string  `json:"non_voting_percent"`
+	Quorum            sdk.Rat `json:"quorum"`
+	Threshold         sdk.Rat `json:"threshold"`
+	Veto              sdk.Rat `json:"veto"`
+	Result            string  `json:"result"` // approved, rejected, no quorum, not determined or no validator
+}
+
+// tallyParams returns the quorum, approval threshold and veto threshold of the proposal type,
+// params loaded from an older state fall back to the former two-thirds supermajority
+func tallyParams(ptype string) (quorum, threshold, veto sdk.Rat) {
+	params := utils.GetParams()
+	switch ptype {
+	case TRANSFER_FUND_PROPOSAL, GRANT_PROPOSAL, REVOKE_GRANT_PROPOSAL:
+		quorum, threshold, veto = params.TransferFundProposalQuorum, params.TransferFundProposalThreshold, params.TransferFundProposalVeto
+	case CHANGE_PARAM_PROPOSAL, CHANGE_PARAMS_PROPOSAL:
+		quorum, threshold, veto = params.ChangeParamProposalQuorum, params.ChangeParamProposalThreshold, params.ChangeParamProposalVeto
+	case DEPLOY_LIBENI_PROPOSAL:
+		quorum, threshold, veto = params.DeployLibEniProposalQuorum, params.DeployLibEniProposalThreshold, params.DeployLibEniProposalVeto
+	case RETIRE_PROGRAM_PROPOSAL:
+		quorum, threshold, veto = params.RetireProgramProposalQuorum, params.RetireProgramProposalThreshold, params.RetireProgramProposalVeto
+	case UPGRADE_PROGRAM_PROPOSAL:
+		quorum, threshold, veto = params.UpgradeProgramProposalQuorum, params.UpgradeProgramProposalThreshold, params.UpgradeProgramProposalVeto
+	case CALL_CONTRACT_PROPOSAL:
+		quorum, threshold, veto = params.CallContractProposalQuorum, params.CallContractProposalThreshold, params.CallContractProposalVeto
+	}
+
+	if quorum.IsNil() {
+		quorum = sdk.NewRat(2, 3)
+	}
+	if threshold.IsNil() {
+		threshold = sdk.NewRat(2, 3)
+	}
+	if veto.IsNil() {
+		veto = sdk.NewRat(1, 3)
+	}
+	return
+}
+
+// Tally counts the votes of the current validators on the proposal.
+//
+// While the proposal is open, it is decided as soon as the outcome can no longer change:
+// approved once the yes votes reach the threshold of the total voting power with quorum,
+// rejected once the veto threshold of the total voting power is reached or approval
+// became impossible. At expiry (final) the quorum has to be met, then the proposal is
+// rejected if vetoed and approved if the yes votes reach the threshold of the
+// non-abstaining votes. The votes of the excluded voter are ignored.
+func Tally(pid string, final bool, excluded *common.Address) *TallyResult {
+	proposal := GetProposalById(pid)
+	if proposal == nil {
+		return nil
+	}
+
+	return tallyVotes(proposal, GetVotesByPid(pid), stake.GetCandidates().Validators(), final, excluded)
+}
+
+// QueryTally computes the current tally of the proposal from the committed state
+func QueryTally(pid string) *TallyResult {
+	proposal := QueryProposal(pid)
+	if proposal == nil {
+		return nil
+	}
+
+	return tallyVotes(proposal, QueryVotes(pid), stake.QueryCandidates().Validators(), false, nil)
+}
+
+func tallyVotes(proposal *Proposal, allVotes []*Vote, validators stake.Validators, final bool, excluded *common.Address) *TallyResult {
+	tally := &TallyResult{}
+	tally.Quorum, tally.Threshold, tally.Veto = tallyParams(proposal.Type)
+
+	votes := make(map[string]string)
+	for _, vo := range allVotes {
+		if excluded != nil && vo.Voter == *excluded {
+			continue
+		}
+		votes[vo.Voter.String()] = vo.Answer
+	}
+
+	// should check voter is still valid validator first
+	for _, va := range validators {
+		tally.Total += va.VotingPower
+		switch votes[va.OwnerAddress] {
+		case VOTE_YES:
+			tally.Yes += va.VotingPower
+		case VOTE_NO:
+			tally.No += va.VotingPower
+		case VOTE_NO_WITH_VETO:
+			tally.NoWithVeto += va.VotingPower
+		case VOTE_ABSTAIN:
+			tally.Abstain += va.VotingPower
+		default:
+			tally.NonVoting += va.VotingPower
+		}
+	}
+
+	if tally.Total == 0 {
+		tally.Result = "no validator"
+		return tally
+	}
+
+	tally.YesPercent = percent(tally.Yes, tally.Total)
+	tally.NoPercent = percent(tally.No, tally.Total)
+	tally.NoWithVetoPercent = percent(tally.NoWithVeto, tally.Total)
+	tally.AbstainPercent = percent(tally.Abstain, tally.Total)
+	tally.NonVotingPercent = percent(tally.NonVoting, tally.Total)
+
+	voted := tally.Total - tally.NonVoting
+	against := tally.No + tally.NoWithVeto
+	cast := tally.Yes + against
+	total := sdk.NewRat(tally.Total, 1)
+	quorumReached := sdk.NewRat(voted, 1).GTE(total.Mul(tally.Quorum))
+
+	if !final {
+		switch {
+		case tally.Veto.GT(sdk.ZeroRat) && sdk.NewRat(tally.NoWithVeto, 1).GTE(total.Mul(tally.Veto)):
+			tally.Result = "rejected"
+		case quorumReached && sdk.NewRat(tally.Yes, 1).GTE(total.Mul(tally.Threshold)):
+			tally.Result = "approved"
+		case sdk.NewRat(against, 1).GT(total.Mul(sdk.OneRat.Sub(tally.Threshold))):
+			tally.Result = "rejected"
+		default:
+			tally.Result = "not determined"
+		}
+		return tally
+	}
+
+	switch {
+	case !quorumReached || voted == 0:
+		tally.Result = "no quorum"
+	case tally.Veto.GT(sdk.ZeroRat) && sdk.NewRat(tally.NoWithVeto, 1).GTE(sdk.NewRat(voted, 1).Mul(tally.Veto)):
+		tally.Result = "rejected"
+	case cast > 0 && sdk.NewRat(tally.Yes, 1).GTE(sdk.NewRat(cast, 1).Mul(tally.Threshold)):
+		tally.Result = "approved"
+	default:
+		tally.Result = "rejected"
+	}
+	return tally
+}
+
+func percent(part, total int64) string {
+	return sdk.NewRat(part*100, total).FloatString(2)
+}
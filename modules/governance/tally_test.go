@@ -0,0 +1,87 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/second-state/devchain/modules/stake"
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/utils"
+)
+
+func setTallyParams(quorum, threshold, veto sdk.Rat) {
+	p := utils.DefaultParams()
+	p.TransferFundProposalQuorum = quorum
+	p.TransferFundProposalThreshold = threshold
+	p.TransferFundProposalVeto = veto
+	utils.SetParams(p)
+}
+
+func tallyValidator(i int64, power int64) stake.Validator {
+	return stake.Validator{OwnerAddress: common.BigToAddress(big.NewInt(i)).String(), VotingPower: power}
+}
+
+func tallyVote(i int64, answer string) *Vote {
+	return &Vote{ProposalId: "pid", Voter: common.BigToAddress(big.NewInt(i)), Answer: answer}
+}
+
+func TestTallyVotes(t *testing.T) {
+	assert := assert.New(t)
+
+	proposal := &Proposal{Id: "pid", Type: TRANSFER_FUND_PROPOSAL}
+	three := stake.Validators{tallyValidator(1, 10), tallyValidator(2, 10), tallyValidator(3, 10)}
+	four := append(stake.Validators{tallyValidator(4, 10)}, three...)
+
+	cases := []struct {
+		name       string
+		quorum     sdk.Rat
+		validators stake.Validators
+		votes      []*Vote
+		final      bool
+		result     string
+	}{
+		{"no validator", sdk.NewRat(1, 2), nil, nil, false, "no validator"},
+
+		// while the proposal is open
+		{"threshold reached", sdk.NewRat(1, 2), three, []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_YES)}, false, "approved"},
+		{"threshold not reached", sdk.NewRat(1, 2), four, []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_YES)}, false, "not determined"},
+		{"threshold reached without quorum", sdk.NewRat(3, 4), three, []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_YES)}, false, "not determined"},
+		{"threshold reached with quorum", sdk.NewRat(3, 4), three, []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_YES), tallyVote(3, VOTE_ABSTAIN)}, false, "approved"},
+		{"approval still possible", sdk.NewRat(1, 2), three, []*Vote{tallyVote(1, VOTE_NO)}, false, "not determined"},
+		{"approval impossible", sdk.NewRat(1, 2), four, []*Vote{tallyVote(1, VOTE_NO), tallyVote(2, VOTE_NO)}, false, "rejected"},
+		{"veto reached", sdk.NewRat(1, 2), three, []*Vote{tallyVote(1, VOTE_NO_WITH_VETO)}, false, "rejected"},
+		{"veto not reached", sdk.NewRat(1, 2), four, []*Vote{tallyVote(1, VOTE_NO_WITH_VETO)}, false, "not determined"},
+
+		// at expiry
+		{"final without quorum", sdk.NewRat(1, 2), four, []*Vote{tallyVote(1, VOTE_YES)}, true, "no quorum"},
+		{"final with quorum", sdk.NewRat(1, 2), four, []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_ABSTAIN)}, true, "approved"},
+		{"final threshold reached", sdk.NewRat(1, 2), three, []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_YES), tallyVote(3, VOTE_NO)}, true, "approved"},
+		{"final threshold not reached", sdk.NewRat(1, 2), four, []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_NO)}, true, "rejected"},
+		{"final veto reached", sdk.NewRat(1, 2), three, []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_YES), tallyVote(3, VOTE_NO_WITH_VETO)}, true, "rejected"},
+		{"final abstain only", sdk.NewRat(1, 2), four, []*Vote{tallyVote(1, VOTE_ABSTAIN), tallyVote(2, VOTE_ABSTAIN)}, true, "rejected"},
+	}
+
+	for _, c := range cases {
+		setTallyParams(c.quorum, sdk.NewRat(2, 3), sdk.NewRat(1, 3))
+		tally := tallyVotes(proposal, c.votes, c.validators, c.final, nil)
+		assert.Equal(c.result, tally.Result, c.name)
+	}
+}
+
+func TestTallyVotesExcluded(t *testing.T) {
+	assert := assert.New(t)
+
+	setTallyParams(sdk.NewRat(1, 2), sdk.NewRat(2, 3), sdk.NewRat(1, 3))
+	proposal := &Proposal{Id: "pid", Type: TRANSFER_FUND_PROPOSAL}
+	validators := stake.Validators{tallyValidator(1, 10), tallyValidator(2, 10), tallyValidator(3, 10)}
+	votes := []*Vote{tallyVote(1, VOTE_YES), tallyVote(2, VOTE_YES)}
+
+	excluded := common.BigToAddress(big.NewInt(2))
+	tally := tallyVotes(proposal, votes, validators, false, &excluded)
+	assert.Equal(int64(10), tally.Yes)
+	assert.Equal(int64(20), tally.NonVoting)
+	assert.Equal("not determined", tally.Result)
+}
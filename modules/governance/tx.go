@@ -1,7 +1,10 @@
 package governance
 
 import (
+	"math/big"
+
 	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -18,12 +21,22 @@ const (
 	ByteTxRetireProgramPropose     = 0xA4
 	ByteTxUpgradeProgramPropose    = 0xA5
 	ByteTxVote                     = 0xA6
+	ByteTxCallContractPropose      = 0xA7
+	ByteTxCancelProposal           = 0xA8
+	ByteTxChangeParamsPropose      = 0xA9
+	ByteTxGrantPropose             = 0xAA
+	ByteTxRevokeGrantPropose       = 0xAB
 	TypeTxTransferFundPropose      = governanceModuleName + "/propose/transfer_fund"
 	TypeTxChangeParamPropose       = governanceModuleName + "/propose/change_param"
 	TypeTxDeployLibEniPropose      = governanceModuleName + "/propose/deploy_libeni"
 	TypeTxRetireProgramPropose     = governanceModuleName + "/propose/retire_program"
 	TypeTxUpgradeProgramPropose    = governanceModuleName + "/propose/upgrade_program"
 	TypeTxVote                     = governanceModuleName + "/vote"
+	TypeTxCallContractPropose      = governanceModuleName + "/propose/call_contract"
+	TypeTxCancelProposal           = governanceModuleName + "/cancel"
+	TypeTxChangeParamsPropose      = governanceModuleName + "/propose/change_params"
+	TypeTxGrantPropose             = governanceModuleName + "/propose/grant"
+	TypeTxRevokeGrantPropose       = governanceModuleName + "/propose/revoke_grant"
 )
 
 func init() {
@@ -33,11 +46,17 @@ func init() {
 	sdk.TxMapper.RegisterImplementation(TxRetireProgramPropose{}, TypeTxRetireProgramPropose, ByteTxRetireProgramPropose)
 	sdk.TxMapper.RegisterImplementation(TxUpgradeProgramPropose{}, TypeTxUpgradeProgramPropose, ByteTxUpgradeProgramPropose)
 	sdk.TxMapper.RegisterImplementation(TxVote{}, TypeTxVote, ByteTxVote)
+	sdk.TxMapper.RegisterImplementation(TxCallContractPropose{}, TypeTxCallContractPropose, ByteTxCallContractPropose)
+	sdk.TxMapper.RegisterImplementation(TxCancelProposal{}, TypeTxCancelProposal, ByteTxCancelProposal)
+	sdk.TxMapper.RegisterImplementation(TxChangeParamsPropose{}, TypeTxChangeParamsPropose, ByteTxChangeParamsPropose)
+	sdk.TxMapper.RegisterImplementation(TxGrantPropose{}, TypeTxGrantPropose, ByteTxGrantPropose)
+	sdk.TxMapper.RegisterImplementation(TxRevokeGrantPropose{}, TypeTxRevokeGrantPropose, ByteTxRevokeGrantPropose)
 }
 
 //Verify interface at compile time
 var _, _, _, _, _ sdk.TxInner = &TxTransferFundPropose{}, &TxChangeParamPropose{}, &TxDeployLibEniPropose{}, &TxRetireProgramPropose{}, &TxUpgradeProgramPropose{}
-var _ sdk.TxInner = &TxVote{}
+var _, _, _, _ sdk.TxInner = &TxVote{}, &TxCallContractPropose{}, &TxCancelProposal{}, &TxChangeParamsPropose{}
+var _, _ sdk.TxInner = &TxGrantPropose{}, &TxRevokeGrantPropose{}
 
 type TxTransferFundPropose struct {
 	From               *common.Address   `json:"transfer_from"`
@@ -169,6 +188,9 @@ type TxVote struct {
 }
 
 func (tx TxVote) ValidateBasic() error {
+	if !IsValidVoteAnswer(tx.Answer) {
+		return ErrInvalidVoteAnswer()
+	}
 	return nil
 }
 
@@ -180,3 +202,145 @@ func NewTxVote(pid string, answer string) sdk.Tx {
 }
 
 func (tx TxVote) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+type TxCallContractPropose struct {
+	ContractAddress    *common.Address `json:"contract_address"`
+	Value              string          `json:"value"`
+	Data               string          `json:"data"`
+	GasLimit           uint64          `json:"gas_limit"`
+	Reason             string          `json:"reason"`
+	ExpireTimestamp    *int64          `json:"expire_timestamp"`
+	ExpireBlockHeight  *int64          `json:"expire_block_height"`
+}
+
+func (tx TxCallContractPropose) ValidateBasic() error {
+	return nil
+}
+
+func NewTxCallContractPropose(contractAddr *common.Address, value, data string, gasLimit uint64, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxCallContractPropose{
+		contractAddr,
+		value,
+		data,
+		gasLimit,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxCallContractPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+type TxCancelProposal struct {
+	ProposalId       string            `json:"proposal_id"`
+}
+
+func (tx TxCancelProposal) ValidateBasic() error {
+	return nil
+}
+
+func NewTxCancelProposal(pid string) sdk.Tx {
+	return TxCancelProposal{
+		pid,
+	}.Wrap()
+}
+
+func (tx TxCancelProposal) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+type TxChangeParamsPropose struct {
+	Params             []utils.ParamChange `json:"params"`
+	Reason             string              `json:"reason"`
+	ExpireTimestamp    *int64              `json:"expire_timestamp"`
+	ExpireBlockHeight  *int64              `json:"expire_block_height"`
+}
+
+func (tx TxChangeParamsPropose) ValidateBasic() error {
+	if len(tx.Params) == 0 {
+		return ErrInsufficientParameters()
+	}
+	names := make(map[string]bool)
+	for _, p := range tx.Params {
+		if names[p.Name] {
+			return ErrInvalidParameter()
+		}
+		names[p.Name] = true
+	}
+	return nil
+}
+
+func NewTxChangeParamsPropose(params []utils.ParamChange, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxChangeParamsPropose{
+		params,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxChangeParamsPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxGrantPropose proposes to pay the amount to the receiver in tranches,
+// one every interval blocks once the proposal is approved
+type TxGrantPropose struct {
+	From               *common.Address   `json:"transfer_from"`
+	To                 *common.Address   `json:"transfer_to"`
+	Amount             string            `json:"amount"`
+	Tranches           uint64            `json:"tranches"`
+	Interval           int64             `json:"interval"`
+	Reason             string            `json:"reason"`
+	ExpireTimestamp    *int64            `json:"expire_timestamp"`
+	ExpireBlockHeight  *int64            `json:"expire_block_height"`
+}
+
+func (tx TxGrantPropose) ValidateBasic() error {
+	if tx.From == nil || tx.To == nil || tx.Tranches == 0 || tx.Interval <= 0 {
+		return ErrInvalidParameter()
+	}
+	// every tranche pays at least one unit of the amount
+	amount, ok := new(big.Int).SetString(tx.Amount, 10)
+	if !ok || amount.Cmp(new(big.Int).SetUint64(tx.Tranches)) < 0 {
+		return ErrInvalidParameter()
+	}
+	return nil
+}
+
+func NewTxGrantPropose(fromAddr *common.Address, toAddr *common.Address, amount string, tranches uint64, interval int64, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxGrantPropose{
+		fromAddr,
+		toAddr,
+		amount,
+		tranches,
+		interval,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxGrantPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// TxRevokeGrantPropose proposes to stop paying a grant and refund its unpaid remainder
+type TxRevokeGrantPropose struct {
+	GrantId            string            `json:"grant_id"`
+	Reason             string            `json:"reason"`
+	ExpireTimestamp    *int64            `json:"expire_timestamp"`
+	ExpireBlockHeight  *int64            `json:"expire_block_height"`
+}
+
+func (tx TxRevokeGrantPropose) ValidateBasic() error {
+	if tx.GrantId == "" {
+		return ErrInvalidParameter()
+	}
+	return nil
+}
+
+func NewTxRevokeGrantPropose(grantId, reason string, expireTimestamp, expireBlockHeight *int64) sdk.Tx {
+	return TxRevokeGrantPropose{
+		grantId,
+		reason,
+		expireTimestamp,
+		expireBlockHeight,
+	}.Wrap()
+}
+
+func (tx TxRevokeGrantPropose) Wrap() sdk.Tx { return sdk.Tx{tx} }
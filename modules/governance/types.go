@@ -3,6 +3,7 @@ package governance
 import (
 	"encoding/json"
 	"github.com/second-state/devchain/types"
+	"github.com/second-state/devchain/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/crypto/ripemd160"
 )
@@ -12,6 +13,24 @@ const CHANGE_PARAM_PROPOSAL = "change_param"
 const DEPLOY_LIBENI_PROPOSAL = "deploy_libeni"
 const RETIRE_PROGRAM_PROPOSAL = "retire_program"
 const UPGRADE_PROGRAM_PROPOSAL = "upgrade_program"
+const CALL_CONTRACT_PROPOSAL = "call_contract"
+const CHANGE_PARAMS_PROPOSAL = "change_params"
+const GRANT_PROPOSAL = "grant"
+const REVOKE_GRANT_PROPOSAL = "revoke_grant"
+
+// vote options
+const VOTE_YES = "Y"
+const VOTE_NO = "N"
+const VOTE_ABSTAIN = "A"
+const VOTE_NO_WITH_VETO = "V"
+
+func IsValidVoteAnswer(answer string) bool {
+	switch answer {
+	case VOTE_YES, VOTE_NO, VOTE_ABSTAIN, VOTE_NO_WITH_VETO:
+		return true
+	}
+	return false
+}
 
 type Proposal struct {
 	Id                string
@@ -170,6 +189,130 @@ func NewUpgradeProgramProposal(id string, proposer *common.Address, blockHeight
 	}
 }
 
+func NewCallContractProposal(id string, proposer *common.Address, blockHeight int64, contractAddr *common.Address, value, data string, gasLimit uint64, reason string, expireTimestamp, expireBlockHeight int64) *Proposal {
+	return &Proposal{
+		id,
+		CALL_CONTRACT_PROPOSAL,
+		proposer,
+		blockHeight,
+		expireTimestamp,
+		expireBlockHeight,
+		"",
+		"",
+		0,
+		map[string]interface{}{
+			"contract_address": contractAddr,
+			"value":            value,
+			"data":             data,
+			"gas_limit":        gasLimit,
+			"reason":           reason,
+			"status":           "init",
+			"gas_used":         uint64(0),
+			"return_data":      "",
+			"tx_hash":          "",
+			"logs":             "",
+		},
+	}
+}
+
+func NewChangeParamsProposal(id string, proposer *common.Address, blockHeight int64, params []utils.ParamChange, reason string, expireTimestamp, expireBlockHeight int64) *Proposal {
+	return &Proposal{
+		id,
+		CHANGE_PARAMS_PROPOSAL,
+		proposer,
+		blockHeight,
+		expireTimestamp,
+		expireBlockHeight,
+		"",
+		"",
+		0,
+		map[string]interface{}{
+			"params": params,
+			"reason": reason,
+		},
+	}
+}
+
+// NewGrantProposal creates a grant proposal, the status of the grant goes from init
+// to paying once approved, then to paid or revoked
+func NewGrantProposal(id string, proposer *common.Address, blockHeight int64, fromAddr *common.Address, toAddr *common.Address, amount string, tranches uint64, interval int64, reason string, expireTimestamp, expireBlockHeight int64) *Proposal {
+	return &Proposal{
+		id,
+		GRANT_PROPOSAL,
+		proposer,
+		blockHeight,
+		expireTimestamp,
+		expireBlockHeight,
+		"",
+		"",
+		0,
+		map[string]interface{}{
+			"from":              fromAddr,
+			"to":                toAddr,
+			"amount":            amount,
+			"tranches":          tranches,
+			"interval":          interval,
+			"reason":            reason,
+			"status":            "init",
+			"paid_amount":       "0",
+			"paid_tranches":     uint64(0),
+			"next_block_height": int64(0),
+		},
+	}
+}
+
+func NewRevokeGrantProposal(id string, proposer *common.Address, blockHeight int64, grantId, reason string, expireTimestamp, expireBlockHeight int64) *Proposal {
+	return &Proposal{
+		id,
+		REVOKE_GRANT_PROPOSAL,
+		proposer,
+		blockHeight,
+		expireTimestamp,
+		expireBlockHeight,
+		"",
+		"",
+		0,
+		map[string]interface{}{
+			"grant_id": grantId,
+			"reason":   reason,
+		},
+	}
+}
+
+// Deposit is the amount escrowed by the proposer until the proposal is decided
+type Deposit struct {
+	ProposalId  string
+	Depositor   common.Address
+	Amount      string
+	Status      string // escrowed, refunded or forfeited
+	BlockHeight int64
+}
+
+func (d *Deposit) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(d, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+// ParamChangeLog records a param set by an approved proposal
+type ParamChangeLog struct {
+	ProposalId  string
+	Name        string
+	OldValue    string
+	NewValue    string
+	BlockHeight int64
+}
+
+func (l *ParamChangeLog) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(l, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
 type Vote struct {
 	ProposalId  string
 	Voter       common.Address
@@ -193,3 +336,17 @@ func NewVote(proposalId string, voter common.Address, blockHeight int64, answer
 		answer,
 	}
 }
+
+// ProposalFilter narrows down a proposal listing, zero values match everything.
+// Status is the proposal result (Approved, Rejected, Expired or Canceled), or pending
+// for the undecided ones. The height range applies to the block height the proposal
+// was created at, and both bounds are inclusive.
+type ProposalFilter struct {
+	Type       string          `json:"type"`
+	Status     string          `json:"status"`
+	Proposer   *common.Address `json:"proposer"`
+	FromHeight int64           `json:"fromHeight"`
+	ToHeight   int64           `json:"toHeight"`
+	Offset     int64           `json:"offset"`
+	Limit      int64           `json:"limit"`
+}
@@ -0,0 +1,203 @@
+package schedule
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/second-state/devchain/sdk/dbm"
+)
+
+var (
+	deliverSqlTx *sql.Tx
+
+	// the block being delivered, and the transaction being executed
+	blockHeight    int64
+	blockTimestamp int64
+	deliveringTx   *common.Hash
+
+	// the calls scheduled by the transaction being executed,
+	// they are saved only if the transaction succeeds
+	scheduledTxs []*ScheduledTx
+)
+
+// MaxDueTxs caps the scheduled calls executed in a block,
+// the ones left are executed in the next blocks
+const MaxDueTxs = 100
+
+func SetDeliverSqlTx(tx *sql.Tx) {
+	deliverSqlTx = tx
+}
+
+func ResetDeliverSqlTx() {
+	deliverSqlTx = nil
+}
+
+// SetBlock sets the height and time of the block being delivered
+func SetBlock(height, timestamp int64) {
+	blockHeight = height
+	blockTimestamp = timestamp
+}
+
+// SetDeliveringTx sets the hash of the transaction being delivered, only the calls
+// scheduled by this transaction are recorded, not the ones of simulated calls
+func SetDeliveringTx(hash common.Hash) {
+	deliveringTx = &hash
+	scheduledTxs = nil
+}
+
+// ResetDeliveringTx drops the calls of the delivered transaction which were not saved
+func ResetDeliveringTx() {
+	deliveringTx = nil
+	scheduledTxs = nil
+}
+
+func getDb() *sql.DB {
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+type SqlTxWrapper struct {
+	tx        *sql.Tx
+	withBlock bool
+}
+
+func getSqlTxWrapper() *SqlTxWrapper {
+	var wrapper = &SqlTxWrapper{
+		tx:        deliverSqlTx,
+		withBlock: true,
+	}
+	if wrapper.tx == nil {
+		db := getDb()
+		tx, err := db.Begin()
+		if err != nil {
+			panic(err)
+		}
+		wrapper.tx = tx
+		wrapper.withBlock = false
+	}
+	return wrapper
+}
+
+func (wrapper *SqlTxWrapper) Commit() {
+	if !wrapper.withBlock {
+		if err := wrapper.tx.Commit(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (wrapper *SqlTxWrapper) Rollback() {
+	if !wrapper.withBlock {
+		if err := wrapper.tx.Rollback(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Schedule records a call scheduled by the transaction being delivered
+func Schedule(s *ScheduledTx) {
+	if deliverSqlTx == nil || deliveringTx == nil || *deliveringTx != s.TxHash {
+		return
+	}
+
+	s.BlockHeight = blockHeight
+	s.Status = "pending"
+	scheduledTxs = append(scheduledTxs, s)
+}
+
+// SaveScheduledTxs saves the calls scheduled by the transaction being delivered
+// once it succeeded, the calls of a failed transaction are never executed
+func SaveScheduledTxs() {
+	for _, s := range scheduledTxs {
+		saveScheduledTx(s)
+	}
+	scheduledTxs = nil
+}
+
+func saveScheduledTx(s *ScheduledTx) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into scheduled_txs(tx_hash, from_address, to_address, value, data, due_timestamp, block_height, status, hash) values(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(s.TxHash.Hex(), s.From.String(), s.To.String(), s.Value, s.Data, s.DueTimestamp, s.BlockHeight, s.Status, common.Bytes2Hex(s.Hash()))
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// UpdateScheduledTxResult records the outcome of the execution of the scheduled call
+func UpdateScheduledTxResult(s *ScheduledTx) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("update scheduled_txs set status = ?, gas_used = ?, return_data = ?, result_msg = ?, executed_block_height = ?, call_hash = ?, logs = ?, hash = ? where id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(s.Status, s.GasUsed, s.ReturnData, s.ResultMsg, s.ExecutedBlockHeight, s.CallHash.Hex(), s.Logs, common.Bytes2Hex(s.Hash()), s.Id)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+}
+
+// GetDueTxs returns the pending calls due at the block time, the earliest first,
+// and in the order they were scheduled for the same due time, at most MaxDueTxs
+func GetDueTxs() []*ScheduledTx {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	rows, err := txWrapper.tx.Query("select id, tx_hash, from_address, to_address, value, data, due_timestamp, block_height, status, gas_used, return_data, result_msg, executed_block_height, call_hash, logs from scheduled_txs where status = 'pending' and due_timestamp <= ? order by due_timestamp, block_height, id limit ?", blockTimestamp, MaxDueTxs)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	return composeScheduledTxs(rows)
+}
+
+// QueryScheduledTxs lists the calls scheduled by the transaction
+func QueryScheduledTxs(txHash common.Hash) []*ScheduledTx {
+	rows, err := getDb().Query("select id, tx_hash, from_address, to_address, value, data, due_timestamp, block_height, status, gas_used, return_data, result_msg, executed_block_height, call_hash, logs from scheduled_txs where tx_hash = ? order by id", txHash.Hex())
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	return composeScheduledTxs(rows)
+}
+
+func composeScheduledTxs(rows *sql.Rows) (txs []*ScheduledTx) {
+	for rows.Next() {
+		var txHash, from, to, callHash string
+		s := &ScheduledTx{}
+		err := rows.Scan(&s.Id, &txHash, &from, &to, &s.Value, &s.Data, &s.DueTimestamp, &s.BlockHeight, &s.Status, &s.GasUsed, &s.ReturnData, &s.ResultMsg, &s.ExecutedBlockHeight, &callHash, &s.Logs)
+		if err != nil {
+			panic(err)
+		}
+		s.TxHash = common.HexToHash(txHash)
+		s.From = common.HexToAddress(from)
+		s.To = common.HexToAddress(to)
+		s.CallHash = common.HexToHash(callHash)
+		txs = append(txs, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return
+}
@@ -0,0 +1,37 @@
+package schedule
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/second-state/devchain/types"
+)
+
+// ScheduledTx is a contract call scheduled by a transaction to be executed
+// at the first block whose time reaches the due timestamp
+type ScheduledTx struct {
+	Id                  int64          `json:"id"`
+	TxHash              common.Hash    `json:"tx_hash"`
+	From                common.Address `json:"from"`
+	To                  common.Address `json:"to"`
+	Value               string         `json:"value"`
+	Data                string         `json:"data"`
+	DueTimestamp        int64          `json:"due_timestamp"`
+	BlockHeight         int64          `json:"block_height"`
+	Status              string         `json:"status"` // pending, executed or failed
+	GasUsed             uint64         `json:"gas_used"`
+	ReturnData          string         `json:"return_data"`
+	ResultMsg           string         `json:"result_msg"`
+	ExecutedBlockHeight int64          `json:"executed_block_height"`
+	CallHash            common.Hash    `json:"call_hash"` // the hash of the receipt and logs of the call
+	Logs                string         `json:"logs"`
+}
+
+// the id is left out as it is assigned by the database once saved
+func (s *ScheduledTx) Hash() []byte {
+	excludedFields := []string{"Id"}
+	bs := types.Hash(s, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
@@ -38,6 +38,12 @@ var (
 		RunE:  cmdQueryValidators,
 		Short: "Query a list of all current validators and validator candidates",
 	}
+
+	CmdQueryDelegator = &cobra.Command{
+		Use:   "delegator",
+		RunE:  cmdQueryDelegator,
+		Short: "Query the current stake status of a delegator",
+	}
 )
 
 func init() {
@@ -46,6 +52,7 @@ func init() {
 	fsAddr.String(FlagAddress, "", "account address")
 
 	CmdQueryValidator.Flags().AddFlagSet(fsAddr)
+	CmdQueryDelegator.Flags().AddFlagSet(fsAddr)
 }
 
 func cmdQueryValidators(cmd *cobra.Command, args []string) error {
@@ -69,6 +76,19 @@ func cmdQueryValidator(cmd *cobra.Command, args []string) error {
 	return Foutput(b)
 }
 
+func cmdQueryDelegator(cmd *cobra.Command, args []string) error {
+	address := viper.GetString(FlagAddress)
+	if address == "" {
+		return fmt.Errorf("please enter delegator address using --address")
+	}
+
+	b, err := Get("/delegator", []byte(address))
+	if err != nil {
+		return err
+	}
+	return Foutput(b)
+}
+
 func Get(path string, params []byte) ([]byte, error) {
 	node := commands.GetNode()
 	resp, err := node.ABCIQuery(path, params)
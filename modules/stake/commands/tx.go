@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/second-state/devchain/modules/stake"
+	"github.com/second-state/devchain/sdk"
 	txcmd "github.com/second-state/devchain/sdk/client/commands/txs"
 	"github.com/second-state/devchain/types"
 )
@@ -100,13 +101,33 @@ var (
 		Short: "Accept the candidate's account update request and become a candidate",
 		RunE:  cmdAcceptCandidacyAccountUpdate,
 	}
+	CmdCancelCandidacyAccountUpdate = &cobra.Command{
+		Use:   "cancel-candidacy-account-update",
+		Short: "Cancel a pending account update request of the candidate",
+		RunE:  cmdCancelCandidacyAccountUpdate,
+	}
+	CmdDelegate = &cobra.Command{
+		Use:   "delegate",
+		Short: "Bond CMTs to a validator/candidate",
+		RunE:  cmdDelegate,
+	}
+	CmdUnbond = &cobra.Command{
+		Use:   "unbond",
+		Short: "Unbond CMTs from a validator/candidate",
+		RunE:  cmdUnbond,
+	}
+	CmdUnjail = &cobra.Command{
+		Use:   "unjail",
+		Short: "Allows a jailed validator to rejoin the candidates once its jail period ends",
+		RunE:  cmdUnjail,
+	}
 )
 
 func init() {
 
 	// define the flags
 	fsPk := flag.NewFlagSet("", flag.ContinueOnError)
-	fsPk.String(FlagPubKey, "", "PubKey of the validator-candidate")
+	fsPk.String(FlagPubKey, "", "PubKey of the validator-candidate, base64 encoded ed25519 or secp256k1 key")
 
 	fsAmount := flag.NewFlagSet("", flag.ContinueOnError)
 	fsAmount.String(FlagAmount, "", "Amount of CMTs")
@@ -160,6 +181,13 @@ func init() {
 
 	CmdUpdateCandidacyAccount.Flags().AddFlagSet(fsNewValidatorAddress)
 	CmdAcceptCandidacyAccountUpdate.Flags().AddFlagSet(fsAccountUpdateRequestId)
+	CmdCancelCandidacyAccountUpdate.Flags().AddFlagSet(fsAccountUpdateRequestId)
+
+	CmdDelegate.Flags().AddFlagSet(fsValidatorAddress)
+	CmdDelegate.Flags().AddFlagSet(fsAmount)
+
+	CmdUnbond.Flags().AddFlagSet(fsValidatorAddress)
+	CmdUnbond.Flags().AddFlagSet(fsAmount)
 }
 
 func cmdDeclareCandidacy(cmd *cobra.Command, args []string) error {
@@ -233,6 +261,11 @@ func cmdDeactivateCandidacy(cmd *cobra.Command, args []string) error {
 	return txcmd.DoTx(tx)
 }
 
+func cmdUnjail(cmd *cobra.Command, args []string) error {
+	tx := stake.NewTxUnjail()
+	return txcmd.DoTx(tx)
+}
+
 func cmdUpdateCandidacyAccount(cmd *cobra.Command, args []string) error {
 	newCandidateAddress := common.HexToAddress(viper.GetString(FlagNewCandidateAddress))
 	if newCandidateAddress.String() == "" {
@@ -252,3 +285,47 @@ func cmdAcceptCandidacyAccountUpdate(cmd *cobra.Command, args []string) error {
 	tx := stake.NewTxAcceptCandidacyAccountUpdate(updateAccountRequestId)
 	return txcmd.DoTx(tx)
 }
+
+func cmdCancelCandidacyAccountUpdate(cmd *cobra.Command, args []string) error {
+	updateAccountRequestId := viper.GetInt64(FlagAccountUpdateRequestId)
+	if updateAccountRequestId == 0 {
+		return fmt.Errorf("account-update-request-id must be present")
+	}
+
+	tx := stake.NewTxCancelCandidacyAccountUpdate(updateAccountRequestId)
+	return txcmd.DoTx(tx)
+}
+
+func cmdDelegate(cmd *cobra.Command, args []string) error {
+	candidateAddress, amount, err := getBondParams()
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxDelegate(candidateAddress, amount)
+	return txcmd.DoTx(tx)
+}
+
+func cmdUnbond(cmd *cobra.Command, args []string) error {
+	candidateAddress, amount, err := getBondParams()
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxUnbond(candidateAddress, amount)
+	return txcmd.DoTx(tx)
+}
+
+func getBondParams() (candidateAddress common.Address, amount string, err error) {
+	if utils.IsBlank(viper.GetString(FlagCandidateAddress)) {
+		return candidateAddress, "", fmt.Errorf("please enter candidate address using --candidate-address")
+	}
+	candidateAddress = common.HexToAddress(viper.GetString(FlagCandidateAddress))
+
+	amount = viper.GetString(FlagAmount)
+	if v, ok := sdk.NewIntFromString(amount); !ok || v.LTE(sdk.ZeroInt) {
+		return candidateAddress, "", fmt.Errorf("please enter a positive amount using --amount")
+	}
+
+	return candidateAddress, amount, nil
+}
@@ -135,7 +135,7 @@ func getCandidatesInternal(cond map[string]interface{}) (candidates Candidates)
 	defer txWrapper.Commit()
 
 	clause, params := buildQueryClause(cond)
-	rows, err := txWrapper.tx.Query("select id, pub_key, address, voting_power, name, website, location, profile, email, verified, active, block_height, state, created_at from candidates"+clause, params...)
+	rows, err := txWrapper.tx.Query("select id, pub_key, address, voting_power, shares, name, website, location, profile, email, verified, active, block_height, state, jailed_until, cooldown_until, created_at from candidates"+clause, params...)
 	if err != nil {
 		panic(err)
 	}
@@ -146,9 +146,9 @@ func getCandidatesInternal(cond map[string]interface{}) (candidates Candidates)
 
 func composeCandidateResults(rows *sql.Rows) (candidates Candidates) {
 	for rows.Next() {
-		var pubKey, address, name, website, location, profile, email, state, verified, active string
-		var id, votingPower, blockHeight, createdAt int64
-		err := rows.Scan(&id, &pubKey, &address, &votingPower, &name, &website, &location, &profile, &email, &verified, &active, &blockHeight, &state, &createdAt)
+		var pubKey, address, shares, name, website, location, profile, email, state, verified, active string
+		var id, votingPower, blockHeight, jailedUntil, cooldownUntil, createdAt int64
+		err := rows.Scan(&id, &pubKey, &address, &votingPower, &shares, &name, &website, &location, &profile, &email, &verified, &active, &blockHeight, &state, &jailedUntil, &cooldownUntil, &createdAt)
 		if err != nil {
 			panic(err)
 		}
@@ -161,16 +161,19 @@ func composeCandidateResults(rows *sql.Rows) (candidates Candidates) {
 			Email:    email,
 		}
 		candidate := &Candidate{
-			Id:           id,
-			PubKey:       pk,
-			OwnerAddress: address,
-			VotingPower:  votingPower,
-			Description:  description,
-			Verified:     verified,
-			CreatedAt:    createdAt,
-			Active:       active,
-			BlockHeight:  blockHeight,
-			State:        state,
+			Id:            id,
+			PubKey:        pk,
+			OwnerAddress:  address,
+			VotingPower:   votingPower,
+			Shares:        shares,
+			Description:   description,
+			Verified:      verified,
+			CreatedAt:     createdAt,
+			Active:        active,
+			BlockHeight:   blockHeight,
+			State:         state,
+			JailedUntil:   jailedUntil,
+			CooldownUntil: cooldownUntil,
 		}
 		candidates = append(candidates, candidate)
 	}
@@ -185,17 +188,18 @@ func SaveCandidate(candidate *Candidate) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	stmt, err := txWrapper.tx.Prepare("insert into candidates(pub_key, address, voting_power, name, website, location, profile, email, verified, active, hash, block_height, state, created_at) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	stmt, err := txWrapper.tx.Prepare("insert into candidates(pub_key, address, voting_power, shares, name, website, location, profile, email, verified, active, hash, block_height, state, jailed_until, cooldown_until, created_at) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		panic(err)
 	}
 
 	defer stmt.Close()
 
-	_, err = stmt.Exec(
+	res, err := stmt.Exec(
 		types.PubKeyString(candidate.PubKey),
 		candidate.OwnerAddress,
 		candidate.VotingPower,
+		candidate.ParseShares().String(),
 		candidate.Description.Name,
 		candidate.Description.Website,
 		candidate.Description.Location,
@@ -206,18 +210,26 @@ func SaveCandidate(candidate *Candidate) {
 		common.Bytes2Hex(candidate.Hash()),
 		candidate.BlockHeight,
 		candidate.State,
+		candidate.JailedUntil,
+		candidate.CooldownUntil,
 		candidate.CreatedAt,
 	)
 	if err != nil {
 		panic(err)
 	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		panic(err)
+	}
+	touchedCandidates[id] = true
 }
 
 func updateCandidate(candidate *Candidate) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
 
-	stmt, err := txWrapper.tx.Prepare("update candidates set address = ?, voting_power = ?, name =?, website = ?, location = ?, profile = ?, email = ?, verified = ?, active = ?, hash = ?, state = ?, pub_key = ? where id = ?")
+	stmt, err := txWrapper.tx.Prepare("update candidates set address = ?, voting_power = ?, shares = ?, name =?, website = ?, location = ?, profile = ?, email = ?, verified = ?, active = ?, hash = ?, state = ?, jailed_until = ?, cooldown_until = ?, pub_key = ? where id = ?")
 	if err != nil {
 		panic(err)
 	}
@@ -227,6 +239,7 @@ func updateCandidate(candidate *Candidate) {
 	_, err = stmt.Exec(
 		candidate.OwnerAddress,
 		candidate.VotingPower,
+		candidate.ParseShares().String(),
 		candidate.Description.Name,
 		candidate.Description.Website,
 		candidate.Description.Location,
@@ -236,12 +249,15 @@ func updateCandidate(candidate *Candidate) {
 		candidate.Active,
 		common.Bytes2Hex(candidate.Hash()),
 		candidate.State,
+		candidate.JailedUntil,
+		candidate.CooldownUntil,
 		types.PubKeyString(candidate.PubKey),
 		candidate.Id,
 	)
 	if err != nil {
 		panic(err)
 	}
+	touchedCandidates[candidate.Id] = true
 }
 
 func saveCandidateAccountUpdateRequest(req *CandidateAccountUpdateRequest) int64 {
@@ -268,6 +284,7 @@ func saveCandidateAccountUpdateRequest(req *CandidateAccountUpdateRequest) int64
 	}
 
 	lastInsertId, _ := result.LastInsertId()
+	touchedAccountUpdateRequests[lastInsertId] = true
 	return lastInsertId
 }
 
@@ -290,6 +307,13 @@ func getCandidateAccountUpdateRequestByToAddress(toAddress common.Address) (res
 	return
 }
 
+func getPendingCandidateAccountUpdateRequests() (res []*CandidateAccountUpdateRequest) {
+	cond := make(map[string]interface{})
+	cond["state"] = "PENDING"
+	res = getCandidateAccountUpdateRequestInternal(cond)
+	return
+}
+
 func getCandidateAccountUpdateRequestInternal(cond map[string]interface{}) (reqs []*CandidateAccountUpdateRequest) {
 	txWrapper := getSqlTxWrapper()
 	defer txWrapper.Commit()
@@ -351,4 +375,394 @@ func updateCandidateAccountUpdateRequest(req *CandidateAccountUpdateRequest) {
 	if err != nil {
 		panic(err)
 	}
+	touchedAccountUpdateRequests[req.Id] = true
+}
+
+func SaveDelegation(delegation *Delegation) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into delegations(delegator_address, candidate_id, shares, block_height, hash) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(
+		delegation.DelegatorAddress.String(),
+		delegation.CandidateId,
+		delegation.ParseShares().String(),
+		delegation.BlockHeight,
+		common.Bytes2Hex(delegation.Hash()),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		panic(err)
+	}
+	touchedDelegations[id] = true
+}
+
+func updateDelegation(delegation *Delegation) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("update delegations set shares = ?, block_height = ?, hash = ? where id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(
+		delegation.ParseShares().String(),
+		delegation.BlockHeight,
+		common.Bytes2Hex(delegation.Hash()),
+		delegation.Id,
+	)
+	if err != nil {
+		panic(err)
+	}
+	touchedDelegations[delegation.Id] = true
+}
+
+func removeDelegation(delegation *Delegation) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("delete from delegations where id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(delegation.Id)
+	if err != nil {
+		panic(err)
+	}
+	touchedDelegations[delegation.Id] = true
+}
+
+func GetDelegation(delegatorAddress common.Address, candidateId int64) *Delegation {
+	cond := make(map[string]interface{})
+	cond["delegator_address"] = delegatorAddress.String()
+	cond["candidate_id"] = candidateId
+	delegations := getDelegationsInternal(cond)
+	if len(delegations) == 0 {
+		return nil
+	} else {
+		return delegations[0]
+	}
+}
+
+func getDelegationById(id int64) *Delegation {
+	cond := make(map[string]interface{})
+	cond["id"] = id
+	delegations := getDelegationsInternal(cond)
+	if len(delegations) == 0 {
+		return nil
+	} else {
+		return delegations[0]
+	}
+}
+
+func GetDelegationsByDelegator(delegatorAddress common.Address) []*Delegation {
+	cond := make(map[string]interface{})
+	cond["delegator_address"] = delegatorAddress.String()
+	return getDelegationsInternal(cond)
+}
+
+func GetDelegationsByCandidate(candidateId int64) []*Delegation {
+	cond := make(map[string]interface{})
+	cond["candidate_id"] = candidateId
+	return getDelegationsInternal(cond)
+}
+
+func getDelegationsInternal(cond map[string]interface{}) (delegations []*Delegation) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	clause, params := buildQueryClause(cond)
+	rows, err := txWrapper.tx.Query("select id, delegator_address, candidate_id, shares, block_height from delegations"+clause, params...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	delegations = composeDelegationResults(rows)
+	return
+}
+
+func composeDelegationResults(rows *sql.Rows) (delegations []*Delegation) {
+	for rows.Next() {
+		var delegatorAddress, shares string
+		var id, candidateId, blockHeight int64
+		err := rows.Scan(&id, &delegatorAddress, &candidateId, &shares, &blockHeight)
+		if err != nil {
+			panic(err)
+		}
+
+		delegation := &Delegation{
+			Id:               id,
+			DelegatorAddress: common.HexToAddress(delegatorAddress),
+			CandidateId:      candidateId,
+			Shares:           shares,
+			BlockHeight:      blockHeight,
+		}
+		delegations = append(delegations, delegation)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+	return
+}
+
+func saveUnbonding(unbonding *Unbonding) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into unbondings(candidate_id, delegator_address, amount, block_height, release_block_height, hash) values(?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(
+		unbonding.CandidateId,
+		unbonding.DelegatorAddress.String(),
+		unbonding.Amount,
+		unbonding.BlockHeight,
+		unbonding.ReleaseBlockHeight,
+		common.Bytes2Hex(unbonding.Hash()),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		panic(err)
+	}
+	touchedUnbondings[id] = true
+}
+
+func updateUnbonding(unbonding *Unbonding) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("update unbondings set amount = ?, hash = ? where id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(
+		unbonding.Amount,
+		common.Bytes2Hex(unbonding.Hash()),
+		unbonding.Id,
+	)
+	if err != nil {
+		panic(err)
+	}
+	touchedUnbondings[unbonding.Id] = true
+}
+
+func removeUnbonding(unbonding *Unbonding) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("delete from unbondings where id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(unbonding.Id)
+	if err != nil {
+		panic(err)
+	}
+	touchedUnbondings[unbonding.Id] = true
+}
+
+func getDueUnbondings(height int64) []*Unbonding {
+	return getUnbondingsInternal("release_block_height <= ?", height)
+}
+
+func getUnbondingsByCandidate(candidateId int64) []*Unbonding {
+	return getUnbondingsInternal("candidate_id = ?", candidateId)
+}
+
+func getUnbondingById(id int64) *Unbonding {
+	unbondings := getUnbondingsInternal("id = ?", id)
+	if len(unbondings) == 0 {
+		return nil
+	}
+	return unbondings[0]
+}
+
+func getUnbondingsInternal(clause string, param interface{}) (unbondings []*Unbonding) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	rows, err := txWrapper.tx.Query("select id, candidate_id, delegator_address, amount, block_height, release_block_height from unbondings where "+clause+" order by id", param)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	unbondings = composeUnbondingResults(rows)
+	return
+}
+
+func composeUnbondingResults(rows *sql.Rows) (unbondings []*Unbonding) {
+	for rows.Next() {
+		var delegatorAddress, amount string
+		var id, candidateId, blockHeight, releaseBlockHeight int64
+		err := rows.Scan(&id, &candidateId, &delegatorAddress, &amount, &blockHeight, &releaseBlockHeight)
+		if err != nil {
+			panic(err)
+		}
+
+		unbonding := &Unbonding{
+			Id:                 id,
+			CandidateId:        candidateId,
+			DelegatorAddress:   common.HexToAddress(delegatorAddress),
+			Amount:             amount,
+			BlockHeight:        blockHeight,
+			ReleaseBlockHeight: releaseBlockHeight,
+		}
+		unbondings = append(unbondings, unbonding)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+	return
+}
+
+func saveCandidateVerification(v *CandidateVerification) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into candidate_verifications(candidate_id, verifier, verified, block_height, hash) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(
+		v.CandidateId,
+		v.Verifier.String(),
+		v.Verified,
+		v.BlockHeight,
+		common.Bytes2Hex(v.Hash()),
+	)
+	if err != nil {
+		panic(err)
+	}
+	touchedVerifications[v.CandidateId] = true
+}
+
+func removeCandidateVerifications(candidateId int64) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("delete from candidate_verifications where candidate_id = ?")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(candidateId)
+	if err != nil {
+		panic(err)
+	}
+	touchedVerifications[candidateId] = true
+}
+
+func getCandidateVerifications(candidateId int64) (verifications []*CandidateVerification) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	rows, err := txWrapper.tx.Query("select candidate_id, verifier, verified, block_height from candidate_verifications where candidate_id = ?", candidateId)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	verifications = composeCandidateVerificationResults(rows)
+	return
+}
+
+func composeCandidateVerificationResults(rows *sql.Rows) (verifications []*CandidateVerification) {
+	for rows.Next() {
+		var verifier, verified string
+		var candidateId, blockHeight int64
+		err := rows.Scan(&candidateId, &verifier, &verified, &blockHeight)
+		if err != nil {
+			panic(err)
+		}
+
+		v := &CandidateVerification{
+			CandidateId: candidateId,
+			Verifier:    common.HexToAddress(verifier),
+			Verified:    verified,
+			BlockHeight: blockHeight,
+		}
+		verifications = append(verifications, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+	return
+}
+
+func saveFeeDistribution(fd *FeeDistribution) {
+	txWrapper := getSqlTxWrapper()
+	defer txWrapper.Commit()
+
+	stmt, err := txWrapper.tx.Prepare("insert into fee_distributions(block_height, address, amount, type, hash) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(
+		fd.BlockHeight,
+		fd.Address.String(),
+		fd.Amount,
+		fd.Type,
+		common.Bytes2Hex(fd.Hash()),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func composeFeeDistributionResults(rows *sql.Rows) (fds []*FeeDistribution) {
+	for rows.Next() {
+		var address, amount, typ string
+		var blockHeight int64
+		err := rows.Scan(&blockHeight, &address, &amount, &typ)
+		if err != nil {
+			panic(err)
+		}
+
+		fd := &FeeDistribution{
+			BlockHeight: blockHeight,
+			Address:     common.HexToAddress(address),
+			Amount:      amount,
+			Type:        typ,
+		}
+		fds = append(fds, fd)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+	return
 }
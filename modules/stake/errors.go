@@ -21,6 +21,12 @@ var (
 	errCandidateAlreadyActivated          = fmt.Errorf("Candidate has been activated")
 	errCandidateAlreadyDeactivated        = fmt.Errorf("Candidate has been deactivated")
 	errBadRequest                         = fmt.Errorf("Bad request")
+	errBadDelegation                      = fmt.Errorf("Delegation does not exist")
+	errInsufficientShares                 = fmt.Errorf("Insufficient bonded shares")
+	errCandidateNotJailed                 = fmt.Errorf("Candidate is not jailed")
+	errCandidateStillJailed               = fmt.Errorf("Candidate cannot be unjailed before the jail period ends")
+	errCandidateCoolingDown               = fmt.Errorf("Candidate cannot be activated before the unbonding period ends")
+	errPubKeyTypeNotAllowed               = fmt.Errorf("PubKey type is not allowed for validators")
 
 	invalidInput = errors.CodeTypeBaseInvalidInput
 )
@@ -67,3 +73,27 @@ func ErrCandidateAlreadyActivated() error {
 func ErrCandidateAlreadyDeactivated() error {
 	return errors.WithCode(errCandidateAlreadyDeactivated, errors.CodeTypeBaseInvalidOutput)
 }
+
+func ErrBadDelegation() error {
+	return errors.WithCode(errBadDelegation, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrInsufficientShares() error {
+	return errors.WithCode(errInsufficientShares, errors.CodeTypeBaseInvalidInput)
+}
+
+func ErrCandidateNotJailed() error {
+	return errors.WithCode(errCandidateNotJailed, errors.CodeTypeBaseInvalidOutput)
+}
+
+func ErrCandidateStillJailed() error {
+	return errors.WithCode(errCandidateStillJailed, errors.CodeTypeBaseInvalidOutput)
+}
+
+func ErrCandidateCoolingDown() error {
+	return errors.WithCode(errCandidateCoolingDown, errors.CodeTypeBaseInvalidOutput)
+}
+
+func ErrPubKeyTypeNotAllowed() error {
+	return errors.WithCode(errPubKeyTypeNotAllowed, errors.CodeTypeBaseInvalidInput)
+}
@@ -0,0 +1,107 @@
+package stake
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/second-state/devchain/commons"
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/types"
+	"github.com/second-state/devchain/utils"
+)
+
+// FeeDistribution records the part of a block's gas fees paid to an account
+type FeeDistribution struct {
+	BlockHeight int64          `json:"block_height"`
+	Address     common.Address `json:"address"`
+	Amount      string         `json:"amount"`
+	Type        string         `json:"type"` // proposer, validator or foundation
+}
+
+func (fd *FeeDistribution) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(fd, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+// DistributeBlockFee - pay out the gas fees collected in the hold account during
+// the block. The proposer gets a bonus, the other validators share their part
+// pro rata to their voting power, and whatever is left goes to the foundation.
+func DistributeBlockFee(height int64, proposer []byte, fee sdk.Int) {
+	if fee.LTE(sdk.ZeroInt) {
+		return
+	}
+
+	params := utils.GetParams()
+	remaining := fee
+
+	var others Validators
+	var proposerValidator *Validator
+	for _, v := range GetCandidates().Validators() {
+		if proposerValidator == nil && bytes.Equal(v.PubKey.Address(), proposer) {
+			pv := v
+			proposerValidator = &pv
+			continue
+		}
+		others = append(others, v)
+	}
+
+	if proposerValidator != nil {
+		bonus := mulRatio(fee, params.ProposerFeeRatio)
+		if bonus.GT(remaining) {
+			bonus = remaining
+		}
+		payFee(height, common.HexToAddress(proposerValidator.OwnerAddress), bonus, "proposer")
+		remaining = remaining.Sub(bonus)
+	}
+
+	var totalPower int64
+	for _, v := range others {
+		totalPower += v.VotingPower
+	}
+
+	if totalPower > 0 {
+		pool := mulRatio(fee, params.ValidatorsFeeRatio)
+		if pool.GT(remaining) {
+			pool = remaining
+		}
+		for _, v := range others {
+			amount := pool.Mul(sdk.NewInt(v.VotingPower)).Div(sdk.NewInt(totalPower))
+			payFee(height, common.HexToAddress(v.OwnerAddress), amount, "validator")
+			remaining = remaining.Sub(amount)
+		}
+	}
+
+	// never pay out more than the fees collected in the block, the hold account
+	// also keeps the escrowed coins
+	if remaining.LT(sdk.ZeroInt) {
+		panic(fmt.Sprintf("fee payouts at height %d exceed the collected fees %s", height, fee))
+	}
+	payFee(height, common.HexToAddress(params.FoundationAddress), remaining, "foundation")
+}
+
+func payFee(height int64, to common.Address, amount sdk.Int, typ string) {
+	if amount.LTE(sdk.ZeroInt) {
+		return
+	}
+
+	commons.Transfer(utils.HoldAccount, to, amount)
+	saveFeeDistribution(&FeeDistribution{
+		BlockHeight: height,
+		Address:     to,
+		Amount:      amount.String(),
+		Type:        typ,
+	})
+}
+
+func mulRatio(amount sdk.Int, ratio sdk.Rat) sdk.Int {
+	if ratio.IsNil() {
+		return sdk.ZeroInt
+	}
+	return amount.MulRat(ratio)
+}
@@ -26,6 +26,10 @@ type delegatedProofOfStake interface {
 	deactivateCandidacy(TxDeactivateCandidacy) error
 	updateCandidateAccount(TxUpdateCandidacyAccount, sdk.Int) (int64, error)
 	acceptCandidateAccountUpdateRequest(TxAcceptCandidacyAccountUpdate, sdk.Int) error
+	cancelCandidateAccountUpdateRequest(TxCancelCandidacyAccountUpdate) error
+	delegate(TxDelegate) error
+	unbond(TxUnbond) error
+	unjail(TxUnjail) error
 }
 
 func SetGenesisValidator(val types.GenesisValidator, store state.SimpleDB) error {
@@ -104,6 +108,14 @@ func CheckTx(ctx types.Context, store state.SimpleDB, tx sdk.Tx) (res sdk.CheckR
 	case TxAcceptCandidacyAccountUpdate:
 		gasFee := utils.CalGasFee(params.AcceptCandidateAccountUpdateRequestGas, params.GasPrice)
 		return res, checker.acceptCandidateAccountUpdateRequest(txInner, gasFee)
+	case TxCancelCandidacyAccountUpdate:
+		return res, checker.cancelCandidateAccountUpdateRequest(txInner)
+	case TxDelegate:
+		return res, checker.delegate(txInner)
+	case TxUnbond:
+		return res, checker.unbond(txInner)
+	case TxUnjail:
+		return res, checker.unjail(txInner)
 	}
 
 	return res, errors.ErrUnknownTxType(tx)
@@ -173,6 +185,14 @@ func DeliverTx(ctx types.Context, store state.SimpleDB, tx sdk.Tx, hash []byte)
 			res.GasFee = gasFee.Int
 		}
 		return res, err
+	case TxCancelCandidacyAccountUpdate:
+		return res, deliverer.cancelCandidateAccountUpdateRequest(txInner)
+	case TxDelegate:
+		return res, deliverer.delegate(txInner)
+	case TxUnbond:
+		return res, deliverer.unbond(txInner)
+	case TxUnjail:
+		return res, deliverer.unjail(txInner)
 	}
 
 	return
@@ -203,6 +223,9 @@ func (c check) declareCandidacy(tx TxDeclareCandidacy, gasFee sdk.Int) error {
 	if err != nil {
 		return err
 	}
+	if !types.IsValidatorPubKey(pk) {
+		return ErrPubKeyTypeNotAllowed()
+	}
 
 	// check to see if the pubkey or address has been registered before
 	candidate := GetCandidateByAddress(c.sender)
@@ -220,10 +243,13 @@ func (c check) declareCandidacy(tx TxDeclareCandidacy, gasFee sdk.Int) error {
 
 func (c check) updateCandidacy(tx TxUpdateCandidacy, gasFee sdk.Int) error {
 	if !utils.IsBlank(tx.PubKey) {
-		_, err := types.GetPubKey(tx.PubKey)
+		pk, err := types.GetPubKey(tx.PubKey)
 		if err != nil {
 			return err
 		}
+		if !types.IsValidatorPubKey(pk) {
+			return ErrPubKeyTypeNotAllowed()
+		}
 	}
 
 	candidate := GetCandidateByAddress(c.sender)
@@ -263,8 +289,8 @@ func (c check) verifyCandidacy(tx TxVerifyCandidacy) error {
 		return ErrBadValidatorAddr()
 	}
 
-	// check to see if the request was initiated by a special account
-	if c.sender != common.HexToAddress(utils.GetParams().FoundationAddress) {
+	// check to see if the request was initiated by a member of the verifier committee
+	if !isVerifier(c.params, c.sender) {
 		return ErrVerificationDisallowed()
 	}
 
@@ -282,6 +308,10 @@ func (c check) activateCandidacy(tx TxActivateCandidacy) error {
 		return ErrCandidateAlreadyActivated()
 	}
 
+	if c.ctx.BlockHeight() < candidate.CooldownUntil {
+		return ErrCandidateCoolingDown()
+	}
+
 	return nil
 }
 
@@ -311,8 +341,10 @@ func (c check) updateCandidateAccount(tx TxUpdateCandidacyAccount, gasFee sdk.In
 
 	// check if the new address has been used
 	exists := getCandidateAccountUpdateRequestByToAddress(tx.NewCandidateAddress)
-	if len(exists) > 0 {
-		return 0, ErrBadRequest()
+	for _, req := range exists {
+		if req.State == "PENDING" {
+			return 0, ErrBadRequest()
+		}
 	}
 
 	// check if the address has been changed
@@ -344,6 +376,75 @@ func (c check) acceptCandidateAccountUpdateRequest(tx TxAcceptCandidacyAccountUp
 		return ErrBadRequest()
 	}
 
+	if req.isExpired(c.ctx.BlockHeight()) {
+		return ErrBadRequest()
+	}
+
+	return nil
+}
+
+func (c check) cancelCandidateAccountUpdateRequest(tx TxCancelCandidacyAccountUpdate) error {
+	req := getCandidateAccountUpdateRequestById(tx.AccountUpdateRequestId)
+	if req == nil {
+		return ErrBadRequest()
+	}
+
+	// only the owner who created the request can cancel it
+	if req.FromAddress != c.sender || req.State != "PENDING" {
+		return ErrBadRequest()
+	}
+
+	return nil
+}
+
+func (c check) delegate(tx TxDelegate) error {
+	candidate := GetCandidateByAddress(tx.CandidateAddress)
+	if candidate == nil {
+		return ErrBadValidatorAddr()
+	}
+
+	// check if the delegator has sufficient funds
+	amount := utils.ParseInt(tx.Amount)
+	if err := checkBalance(c.ctx.EthappState(), c.sender, amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c check) unbond(tx TxUnbond) error {
+	candidate := GetCandidateByAddress(tx.CandidateAddress)
+	if candidate == nil {
+		return ErrBadValidatorAddr()
+	}
+
+	delegation := GetDelegation(c.sender, candidate.Id)
+	if delegation == nil {
+		return ErrBadDelegation()
+	}
+
+	amount := utils.ParseInt(tx.Amount)
+	if delegation.ParseShares().LT(amount) {
+		return ErrInsufficientShares()
+	}
+
+	return nil
+}
+
+func (c check) unjail(tx TxUnjail) error {
+	candidate := GetCandidateByAddress(c.sender)
+	if candidate == nil {
+		return ErrBadValidatorAddr()
+	}
+
+	if !candidate.IsJailed() {
+		return ErrCandidateNotJailed()
+	}
+
+	if c.ctx.BlockHeight() < candidate.JailedUntil {
+		return ErrCandidateStillJailed()
+	}
+
 	return nil
 }
 
@@ -385,6 +486,10 @@ func (d deliver) declareCandidacy(tx TxDeclareCandidacy, gasFee sdk.Int) error {
 		return err
 	}
 
+	// charge the gas fee, it is paid out at the end of the block
+	d.ctx.EthappState().SubBalance(d.sender, gasFee.Int)
+	d.ctx.EthappState().AddBalance(utils.HoldAccount, gasFee.Int)
+
 	SaveCandidate(candidate)
 	return nil
 }
@@ -396,11 +501,15 @@ func (d deliver) declareGenesisCandidacy(tx TxDeclareCandidacy, val types.Genesi
 		return err
 	}
 
+	// the genesis power counts as stake of the candidate, no coins are escrowed for it
+	// so it is not recorded as a delegation which could be unbonded and paid out
 	power, _ := strconv.ParseInt(val.Power, 10, 64)
+	shares := sdk.NewInt(power).Mul(sdk.E18Int)
 	candidate := &Candidate{
 		PubKey:       pubKey,
 		OwnerAddress: d.sender.String(),
 		VotingPower:  power,
+		Shares:       shares.String(),
 		CreatedAt:    0,
 		Description:  tx.Description,
 		Verified:     "N",
@@ -447,6 +556,10 @@ func (d deliver) updateCandidacy(tx TxUpdateCandidacy, gasFee sdk.Int) error {
 		return err
 	}
 
+	// charge the gas fee, it is paid out at the end of the block
+	d.ctx.EthappState().SubBalance(d.sender, gasFee.Int)
+	d.ctx.EthappState().AddBalance(utils.HoldAccount, gasFee.Int)
+
 	if !utils.IsBlank(tx.PubKey) {
 		newPk, _ := types.GetPubKey(tx.PubKey)
 
@@ -481,19 +594,47 @@ func (d deliver) withdrawCandidacy(tx TxWithdrawCandidacy) error {
 	}
 
 	candidate.Active = "N"
+	candidate.CooldownUntil = d.ctx.BlockHeight() + int64(d.params.UnbondingPeriod)
+
+	// unbond all the stake of the candidate
+	for _, delegation := range GetDelegationsByCandidate(candidate.Id) {
+		d.startUnbonding(candidate.Id, delegation.DelegatorAddress, delegation.ParseShares())
+		removeDelegation(delegation)
+	}
+	candidate.Shares = sdk.ZeroInt.String()
+
 	updateCandidate(candidate)
 	return nil
 }
 
 func (d deliver) verifyCandidacy(tx TxVerifyCandidacy) error {
-	// verify candidacy
 	candidate := GetCandidateByAddress(tx.CandidateAddress)
+	verified := "N"
 	if tx.Verified {
-		candidate.Verified = "Y"
-	} else {
-		candidate.Verified = "N"
+		verified = "Y"
+	}
+
+	// record the approval of the committee member
+	saveCandidateVerification(&CandidateVerification{
+		CandidateId: candidate.Id,
+		Verifier:    d.sender,
+		Verified:    verified,
+		BlockHeight: d.ctx.BlockHeight(),
+	})
+
+	// verify candidacy once enough distinct members agree
+	var approvals uint64
+	for _, v := range getCandidateVerifications(candidate.Id) {
+		if v.Verified == verified && isVerifier(d.params, v.Verifier) {
+			approvals++
+		}
+	}
+
+	if approvals >= d.params.VerifierThreshold {
+		candidate.Verified = verified
+		updateCandidate(candidate)
+		removeCandidateVerifications(candidate.Id)
 	}
-	updateCandidate(candidate)
 	return nil
 }
 
@@ -517,6 +658,7 @@ func (d deliver) deactivateCandidacy(tx TxDeactivateCandidacy) error {
 	}
 
 	candidate.Active = "N"
+	candidate.CooldownUntil = d.ctx.BlockHeight() + int64(d.params.UnbondingPeriod)
 	updateCandidate(candidate)
 	return nil
 }
@@ -578,6 +720,120 @@ func (d deliver) acceptCandidateAccountUpdateRequest(tx TxAcceptCandidacyAccount
 	return nil
 }
 
+func (d deliver) cancelCandidateAccountUpdateRequest(tx TxCancelCandidacyAccountUpdate) error {
+	req := getCandidateAccountUpdateRequestById(tx.AccountUpdateRequestId)
+	if req == nil {
+		return ErrBadRequest()
+	}
+
+	req.State = "CANCELLED"
+	updateCandidateAccountUpdateRequest(req)
+	return nil
+}
+
+func (d deliver) delegate(tx TxDelegate) error {
+	candidate := GetCandidateByAddress(tx.CandidateAddress)
+	if candidate == nil {
+		return ErrBadValidatorAddr()
+	}
+
+	amount := utils.ParseInt(tx.Amount)
+	if err := checkBalance(d.ctx.EthappState(), d.sender, amount); err != nil {
+		return err
+	}
+
+	// lock the delegated coins
+	d.ctx.EthappState().SubBalance(d.sender, amount.Int)
+	d.ctx.EthappState().AddBalance(utils.HoldAccount, amount.Int)
+
+	delegation := GetDelegation(d.sender, candidate.Id)
+	if delegation == nil {
+		SaveDelegation(&Delegation{
+			DelegatorAddress: d.sender,
+			CandidateId:      candidate.Id,
+			Shares:           amount.String(),
+			BlockHeight:      d.ctx.BlockHeight(),
+		})
+	} else {
+		delegation.Shares = delegation.ParseShares().Add(amount).String()
+		delegation.BlockHeight = d.ctx.BlockHeight()
+		updateDelegation(delegation)
+	}
+
+	candidate.Shares = candidate.ParseShares().Add(amount).String()
+	updateCandidate(candidate)
+	return nil
+}
+
+func (d deliver) unbond(tx TxUnbond) error {
+	candidate := GetCandidateByAddress(tx.CandidateAddress)
+	if candidate == nil {
+		return ErrBadValidatorAddr()
+	}
+
+	delegation := GetDelegation(d.sender, candidate.Id)
+	if delegation == nil {
+		return ErrBadDelegation()
+	}
+
+	amount := utils.ParseInt(tx.Amount)
+	remaining := delegation.ParseShares().Sub(amount)
+	if remaining.LT(sdk.ZeroInt) {
+		return ErrInsufficientShares()
+	}
+
+	d.startUnbonding(candidate.Id, d.sender, amount)
+
+	if remaining.Equal(sdk.ZeroInt) {
+		removeDelegation(delegation)
+	} else {
+		delegation.Shares = remaining.String()
+		delegation.BlockHeight = d.ctx.BlockHeight()
+		updateDelegation(delegation)
+	}
+
+	candidate.Shares = candidate.ParseShares().Sub(amount).String()
+	updateCandidate(candidate)
+	return nil
+}
+
+func (d deliver) unjail(tx TxUnjail) error {
+	candidate := GetCandidateByAddress(d.sender)
+	if candidate == nil {
+		return ErrBadValidatorAddr()
+	}
+
+	// the voting power will be restored at the end of the block
+	candidate.JailedUntil = 0
+	updateCandidate(candidate)
+	return nil
+}
+
+// startUnbonding keeps the unbonded coins locked in the hold account
+// until the unbonding period ends
+func (d deliver) startUnbonding(candidateId int64, delegator common.Address, amount sdk.Int) {
+	if amount.LTE(sdk.ZeroInt) {
+		return
+	}
+
+	saveUnbonding(&Unbonding{
+		CandidateId:        candidateId,
+		DelegatorAddress:   delegator,
+		Amount:             amount.String(),
+		BlockHeight:        d.ctx.BlockHeight(),
+		ReleaseBlockHeight: d.ctx.BlockHeight() + int64(d.params.UnbondingPeriod),
+	})
+}
+
+func isVerifier(params *utils.Params, addr common.Address) bool {
+	for _, member := range params.VerifierCommitteeMembers() {
+		if member == addr {
+			return true
+		}
+	}
+	return false
+}
+
 func checkBalance(state *ethstat.StateDB, addr common.Address, amount sdk.Int) error {
 	balance, err := commons.GetBalance(state, addr)
 	if err != nil {
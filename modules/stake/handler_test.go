@@ -0,0 +1,112 @@
+package stake
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/sdk/dbm"
+	"github.com/second-state/devchain/sdk/state"
+	"github.com/second-state/devchain/types"
+	"github.com/second-state/devchain/utils"
+)
+
+// initTestDb points the stake module at a fresh sqlite database holding the
+// candidates table, the returned function removes it again
+func initTestDb(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "stake")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dbm.InitSqliter(filepath.Join(dir, "stake.db")); err != nil {
+		t.Fatal(err)
+	}
+	db, err := dbm.Sqliter.GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlStmt := `
+	create table candidates(id integer not null primary key autoincrement, address text not null, pub_key text not null, voting_power integer default 0, shares text not null default '0', name text not null default '', website text not null default '', location text not null default '', email text not null default '', profile text not null default '', verified text not null default 'N', active text not null default 'Y', state text not null default '', jailed_until integer not null default 0, cooldown_until integer not null default 0, hash text not null default '', block_height integer not null, created_at integer not null);
+	create unique index idx_candidates_pub_key on candidates(pub_key);
+	create unique index idx_candidates_address on candidates(address);
+	`
+	if _, err := db.Exec(sqlStmt); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		dbm.Sqliter.CloseDB()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSecp256k1Validator(t *testing.T) {
+	assert := assert.New(t)
+	defer initTestDb(t)()
+
+	utils.SetParams(utils.DefaultParams())
+	defer types.SetValidatorPubKeyTypes(nil)
+
+	sender := common.HexToAddress("0x7eff122b94897ea5b0e2a9abf47b86337fafebdc")
+	ethState, err := ethstate.New(common.Hash{}, ethstate.NewDatabase(ethdb.NewMemDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ethState.AddBalance(sender, new(big.Int).Mul(big.NewInt(1000), sdk.E18Int.Int))
+
+	ctx := types.NewContext("test", 1, 0, ethState)
+	ctx.WithSigners(sender)
+	store := state.NewMemKVStore()
+
+	pk1 := types.PubKey{secp256k1.GenPrivKey().PubKey()}
+	declare := NewTxDeclareCandidacy(pk1, Description{Name: "secp256k1"})
+
+	// the default consensus params only allow ed25519 validators
+	types.SetValidatorPubKeyTypes(nil)
+	_, err = DeliverTx(ctx, store, declare, nil)
+	assert.NotNil(err)
+
+	consensusParams := tmtypes.DefaultConsensusParams()
+	consensusParams.Validator.PubKeyTypes = []string{tmtypes.ABCIPubKeyTypeEd25519, tmtypes.ABCIPubKeyTypeSecp256k1}
+	types.SetValidatorPubKeyTypes(consensusParams)
+	_, err = DeliverTx(ctx, store, declare, nil)
+	assert.Nil(err)
+
+	// bond some stake to the candidate so that it becomes a validator
+	candidate := GetCandidateByAddress(sender)
+	if !assert.NotNil(candidate) {
+		return
+	}
+	candidate.Shares = sdk.NewInt(100).Mul(sdk.E18Int).String()
+	updateCandidate(candidate)
+
+	change, err := UpdateValidatorSet(store)
+	assert.Nil(err)
+	if assert.Equal([]abci.Validator{{PubKey: pk1.ABCIPubKey(), Power: 100}}, change) {
+		assert.Equal(tmtypes.ABCIPubKeyTypeSecp256k1, change[0].PubKey.Type)
+	}
+
+	// switch the validator over to another secp256k1 key
+	pk2 := types.PubKey{secp256k1.GenPrivKey().PubKey()}
+	_, err = DeliverTx(ctx, store, NewTxUpdateCandidacy(pk2, Description{}), nil)
+	assert.Nil(err)
+
+	change, err = UpdateValidatorSet(store)
+	assert.Nil(err)
+	assert.ElementsMatch([]abci.Validator{{PubKey: pk1.ABCIPubKey(), Power: 0}, {PubKey: pk2.ABCIPubKey(), Power: 100}}, change)
+	assert.Nil(store.Get(utils.PubKeyUpdatesKey))
+	assert.Equal(pk2, GetCandidateByAddress(sender).PubKey)
+}
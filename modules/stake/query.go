@@ -7,9 +7,15 @@ import (
 
 func QueryCandidates() (candidates Candidates) {
 	db := getDb()
+	return queryCandidates(db, listedCandidatesCond())
+}
+
+// listedCandidatesCond selects the candidates listed by the validators query,
+// the snapshots kept for the past heights hold the same set
+func listedCandidatesCond() map[string]interface{} {
 	cond := make(map[string]interface{})
 	cond["active"] = "Y"
-	return queryCandidates(db, cond)
+	return cond
 }
 
 func QueryCandidateByAddress(address common.Address) *Candidate {
@@ -26,7 +32,7 @@ func QueryCandidateByAddress(address common.Address) *Candidate {
 
 func queryCandidates(db *sql.DB, cond map[string]interface{}) (candidates Candidates) {
 	clause, params := buildQueryClause(cond)
-	rows, err := db.Query("select id, pub_key, address, voting_power, name, website, location, profile, email, verified, active, block_height, state, created_at from candidates"+clause, params...)
+	rows, err := db.Query("select id, pub_key, address, voting_power, shares, name, website, location, profile, email, verified, active, block_height, state, jailed_until, cooldown_until, created_at from candidates"+clause, params...)
 	if err != nil {
 		panic(err)
 	}
@@ -34,3 +40,84 @@ func queryCandidates(db *sql.DB, cond map[string]interface{}) (candidates Candid
 	candidates = composeCandidateResults(rows)
 	return
 }
+
+func QueryPendingAccountUpdateRequestsByCandidate(address common.Address) []*CandidateAccountUpdateRequest {
+	candidate := QueryCandidateByAddress(address)
+	if candidate == nil {
+		return nil
+	}
+
+	db := getDb()
+	cond := make(map[string]interface{})
+	cond["candidate_id"] = candidate.Id
+	cond["state"] = "PENDING"
+	return queryCandidateAccountUpdateRequests(db, cond)
+}
+
+func QueryPendingAccountUpdateRequestsByToAddress(address common.Address) []*CandidateAccountUpdateRequest {
+	db := getDb()
+	cond := make(map[string]interface{})
+	cond["to_address"] = address.String()
+	cond["state"] = "PENDING"
+	return queryCandidateAccountUpdateRequests(db, cond)
+}
+
+func queryCandidateAccountUpdateRequests(db *sql.DB, cond map[string]interface{}) (reqs []*CandidateAccountUpdateRequest) {
+	clause, params := buildQueryClause(cond)
+	rows, err := db.Query("select id, candidate_id, from_address, to_address, created_block_height, accepted_block_height, state from candidate_account_update_requests"+clause, params...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+	reqs = composeCandidateAccountUpdateRequestResults(rows)
+	return
+}
+
+func QueryCandidateVerifications(address common.Address) (verifications []*CandidateVerification) {
+	candidate := QueryCandidateByAddress(address)
+	if candidate == nil {
+		return nil
+	}
+
+	db := getDb()
+	rows, err := db.Query("select candidate_id, verifier, verified, block_height from candidate_verifications where candidate_id = ?", candidate.Id)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+	verifications = composeCandidateVerificationResults(rows)
+	return
+}
+
+func QueryDelegationsByDelegator(address common.Address) (delegations []*Delegation) {
+	db := getDb()
+	rows, err := db.Query("select id, delegator_address, candidate_id, shares, block_height from delegations where delegator_address = ?", address.String())
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+	delegations = composeDelegationResults(rows)
+	return
+}
+
+func QueryUnbondingsByDelegator(address common.Address) (unbondings []*Unbonding) {
+	db := getDb()
+	rows, err := db.Query("select id, candidate_id, delegator_address, amount, block_height, release_block_height from unbondings where delegator_address = ? order by id", address.String())
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+	unbondings = composeUnbondingResults(rows)
+	return
+}
+
+func QueryFeeDistributions(height int64) (fds []*FeeDistribution) {
+	db := getDb()
+	rows, err := db.Query("select block_height, address, amount, type from fee_distributions where block_height = ? order by id", height)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+	fds = composeFeeDistributionResults(rows)
+	return
+}
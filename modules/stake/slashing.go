@@ -0,0 +1,172 @@
+package stake
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/second-state/devchain/commons"
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/sdk/state"
+	"github.com/second-state/devchain/utils"
+)
+
+// AbsentValidators maps the address of a validator to the heights of the
+// blocks it failed to sign within the slashing window
+type AbsentValidators map[string][]int64
+
+func loadAbsentValidators(store state.SimpleDB) AbsentValidators {
+	absents := make(AbsentValidators)
+	b := store.Get(utils.AbsentValidatorsKey)
+	if b != nil {
+		json.Unmarshal(b, &absents)
+	}
+	return absents
+}
+
+func (absents AbsentValidators) save(store state.SimpleDB) {
+	if len(absents) == 0 {
+		store.Remove(utils.AbsentValidatorsKey)
+		return
+	}
+
+	b, err := json.Marshal(absents)
+	if err != nil {
+		panic(err)
+	}
+	store.Set(utils.AbsentValidatorsKey, b)
+}
+
+// RecordAbsentValidators - track the validators which did not sign the block at
+// the given height, and jail those that missed more than the allowed number of
+// blocks within the slashing window
+func RecordAbsentValidators(store state.SimpleDB, height int64, signingValidators []abci.SigningValidator) {
+	params := utils.GetParams()
+	if params.SlashingWindow == 0 {
+		return
+	}
+
+	absents := loadAbsentValidators(store)
+	for _, sv := range signingValidators {
+		if sv.SignedLastBlock {
+			continue
+		}
+		key := common.Bytes2Hex(sv.Validator.Address)
+		absents[key] = append(absents[key], height)
+	}
+
+	// slide the window
+	start := height - int64(params.SlashingWindow)
+	for key, heights := range absents {
+		var recent []int64
+		for _, h := range heights {
+			if h > start {
+				recent = append(recent, h)
+			}
+		}
+		if len(recent) == 0 {
+			delete(absents, key)
+		} else {
+			absents[key] = recent
+		}
+	}
+
+	for key, heights := range absents {
+		if uint64(len(heights)) <= params.MaxMissedBlocks {
+			continue
+		}
+
+		candidate := getCandidateByValidatorAddress(common.Hex2Bytes(key))
+		if candidate != nil && !candidate.IsJailed() {
+			candidate.jail(height)
+		}
+		delete(absents, key)
+	}
+
+	absents.save(store)
+}
+
+// SlashByzantineValidators - slash the bonded stake of the validators which
+// have been caught double-signing and jail them
+func SlashByzantineValidators(height int64, evidences []abci.Evidence) {
+	params := utils.GetParams()
+	for _, evidence := range evidences {
+		candidate := getCandidateByValidatorAddress(evidence.Validator.Address)
+		if candidate == nil {
+			continue
+		}
+
+		if !params.DoubleSignSlashRatio.IsNil() {
+			candidate.slash(params.DoubleSignSlashRatio)
+		}
+		candidate.jail(height)
+	}
+}
+
+// jail removes the candidate from the validator set until the jail period ends,
+// the voting power will be updated at the end of the block
+func (c *Candidate) jail(height int64) {
+	c.JailedUntil = height + int64(utils.GetParams().JailPeriod)
+	updateCandidate(c)
+}
+
+// slash burns the given ratio of the stake bonded to the candidate,
+// every delegation and pending unbonding loses its share proportionally
+func (c *Candidate) slash(ratio sdk.Rat) {
+	total := sdk.ZeroInt
+	delegated := sdk.ZeroInt
+	for _, delegation := range GetDelegationsByCandidate(c.Id) {
+		delegated = delegated.Add(delegation.ParseShares())
+		slashed := delegation.ParseShares().MulRat(ratio)
+		if slashed.Equal(sdk.ZeroInt) {
+			continue
+		}
+
+		delegation.Shares = delegation.ParseShares().Sub(slashed).String()
+		updateDelegation(delegation)
+		total = total.Add(slashed)
+	}
+	bonded := total
+
+	// the coins still being unbonded from the candidate are liable as well
+	for _, unbonding := range getUnbondingsByCandidate(c.Id) {
+		amount := utils.ParseInt(unbonding.Amount)
+		slashed := amount.MulRat(ratio)
+		if slashed.Equal(sdk.ZeroInt) {
+			continue
+		}
+
+		unbonding.Amount = amount.Sub(slashed).String()
+		updateUnbonding(unbonding)
+		total = total.Add(slashed)
+	}
+
+	// the genesis stake is not backed by escrowed coins, it is reduced without burning
+	unbacked := c.ParseShares().Sub(delegated).MulRat(ratio)
+	if unbacked.GT(sdk.ZeroInt) {
+		bonded = bonded.Add(unbacked)
+	}
+
+	if bonded.GT(sdk.ZeroInt) {
+		c.Shares = c.ParseShares().Sub(bonded).String()
+		updateCandidate(c)
+	}
+
+	if total.Equal(sdk.ZeroInt) {
+		return
+	}
+
+	// burn the slashed coins
+	commons.Transfer(utils.HoldAccount, utils.MintAccount, total)
+}
+
+func getCandidateByValidatorAddress(address []byte) *Candidate {
+	for _, c := range GetCandidates() {
+		if bytes.Equal(c.PubKey.Address(), address) {
+			return c
+		}
+	}
+	return nil
+}
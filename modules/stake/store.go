@@ -0,0 +1,168 @@
+package stake
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/second-state/devchain/sdk/state"
+	"github.com/second-state/devchain/utils"
+)
+
+// the ids of the records written in the block being delivered, they are copied into
+// the merkle store at commit. The verifications are tracked by candidate id.
+var (
+	touchedCandidates            = make(map[int64]bool)
+	touchedDelegations           = make(map[int64]bool)
+	touchedUnbondings            = make(map[int64]bool)
+	touchedVerifications         = make(map[int64]bool)
+	touchedAccountUpdateRequests = make(map[int64]bool)
+)
+
+// CandidateKey is the store key of the candidate record
+func CandidateKey(id int64) []byte {
+	return idKey(utils.CandidatePrefix, id)
+}
+
+// DelegationKey is the store key of the delegation record
+func DelegationKey(id int64) []byte {
+	return idKey(utils.DelegationPrefix, id)
+}
+
+// UnbondingKey is the store key of the unbonding record
+func UnbondingKey(id int64) []byte {
+	return idKey(utils.UnbondingPrefix, id)
+}
+
+// VerificationKey is the store key of the verification record, the verifications of
+// a candidate share the prefix of the candidate id
+func VerificationKey(candidateId int64, verifier common.Address) []byte {
+	return append(VerificationsPrefix(candidateId), verifier.Bytes()...)
+}
+
+// VerificationsPrefix is the prefix of the store keys of the verifications of the candidate
+func VerificationsPrefix(candidateId int64) []byte {
+	return idKey(utils.VerificationPrefix, candidateId)
+}
+
+// AccountUpdateRequestKey is the store key of the account update request record
+func AccountUpdateRequestKey(id int64) []byte {
+	return idKey(utils.AccountUpdatePrefix, id)
+}
+
+// big endian ids keep the records of a prefix listed in the order they were created
+func idKey(prefix []byte, id int64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], uint64(id))
+	return key
+}
+
+// SyncStore copies the records written in the block into the store, removing the
+// deleted ones. The ids are sorted as the tree depends on the order of the writes.
+func SyncStore(store state.SimpleDB) {
+	for _, id := range sortedIds(touchedCandidates) {
+		if c := GetCandidateById(id); c != nil {
+			setRecord(store, CandidateKey(id), c)
+		} else {
+			store.Remove(CandidateKey(id))
+		}
+	}
+
+	for _, id := range sortedIds(touchedDelegations) {
+		if d := getDelegationById(id); d != nil {
+			setRecord(store, DelegationKey(id), d)
+		} else {
+			store.Remove(DelegationKey(id))
+		}
+	}
+
+	for _, id := range sortedIds(touchedUnbondings) {
+		if u := getUnbondingById(id); u != nil {
+			setRecord(store, UnbondingKey(id), u)
+		} else {
+			store.Remove(UnbondingKey(id))
+		}
+	}
+
+	for _, id := range sortedIds(touchedVerifications) {
+		for _, m := range store.List(VerificationsPrefix(id), VerificationsPrefix(id+1), 0) {
+			store.Remove(m.Key)
+		}
+		setVerificationRecords(store, getCandidateVerifications(id))
+	}
+
+	for _, id := range sortedIds(touchedAccountUpdateRequests) {
+		if req := getCandidateAccountUpdateRequestById(id); req != nil {
+			setRecord(store, AccountUpdateRequestKey(id), req)
+		} else {
+			store.Remove(AccountUpdateRequestKey(id))
+		}
+	}
+
+	ResetStoreChanges()
+}
+
+// ResetStoreChanges forgets the records written in a block which is not committed
+func ResetStoreChanges() {
+	touchedCandidates = make(map[int64]bool)
+	touchedDelegations = make(map[int64]bool)
+	touchedUnbondings = make(map[int64]bool)
+	touchedVerifications = make(map[int64]bool)
+	touchedAccountUpdateRequests = make(map[int64]bool)
+}
+
+// MigrateStore copies all the stake records of the database into the store
+func MigrateStore(store state.SimpleDB) {
+	candidates := GetCandidates()
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Id < candidates[j].Id })
+	for _, c := range candidates {
+		setRecord(store, CandidateKey(c.Id), c)
+	}
+
+	delegations := getDelegationsInternal(make(map[string]interface{}))
+	sort.Slice(delegations, func(i, j int) bool { return delegations[i].Id < delegations[j].Id })
+	for _, d := range delegations {
+		setRecord(store, DelegationKey(d.Id), d)
+	}
+
+	for _, u := range getUnbondingsInternal("id > ?", 0) {
+		setRecord(store, UnbondingKey(u.Id), u)
+	}
+
+	for _, c := range candidates {
+		setVerificationRecords(store, getCandidateVerifications(c.Id))
+	}
+
+	reqs := getCandidateAccountUpdateRequestInternal(make(map[string]interface{}))
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].Id < reqs[j].Id })
+	for _, req := range reqs {
+		setRecord(store, AccountUpdateRequestKey(req.Id), req)
+	}
+}
+
+func setVerificationRecords(store state.SimpleDB, verifications []*CandidateVerification) {
+	sort.Slice(verifications, func(i, j int) bool { return verifications[i].Verifier.Hex() < verifications[j].Verifier.Hex() })
+	for _, v := range verifications {
+		setRecord(store, VerificationKey(v.CandidateId, v.Verifier), v)
+	}
+}
+
+func setRecord(store state.SimpleDB, key []byte, record interface{}) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		panic(err)
+	}
+	store.Set(key, b)
+}
+
+func sortedIds(ids map[int64]bool) []int64 {
+	res := make([]int64, 0, len(ids))
+	for id := range ids {
+		res = append(res, id)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
@@ -22,6 +22,10 @@ const (
 	ByteTxUpdateCandidacyAccount       = 0x63
 	ByteTxAcceptCandidacyAccountUpdate = 0x64
 	ByteTxDeactivateCandidacy          = 0x65
+	ByteTxDelegate                     = 0x66
+	ByteTxUnbond                       = 0x67
+	ByteTxUnjail                       = 0x68
+	ByteTxCancelCandidacyAccountUpdate = 0x69
 	TypeTxDeclareCandidacy             = "stake/declareCandidacy"
 	TypeTxUpdateCandidacy              = "stake/updateCandidacy"
 	TypeTxVerifyCandidacy              = "stake/verifyCandidacy"
@@ -30,6 +34,10 @@ const (
 	TypeTxDeactivateCandidacy          = "stake/deactivateCandidacy"
 	TypeTxUpdateCandidacyAccount       = "stake/updateCandidacyAccount"
 	TypeTxAcceptCandidacyAccountUpdate = "stake/acceptCandidacyAccountUpdate"
+	TypeTxDelegate                     = "stake/delegate"
+	TypeTxUnbond                       = "stake/unbond"
+	TypeTxUnjail                       = "stake/unjail"
+	TypeTxCancelCandidacyAccountUpdate = "stake/cancelCandidacyAccountUpdate"
 )
 
 func init() {
@@ -41,10 +49,15 @@ func init() {
 	sdk.TxMapper.RegisterImplementation(TxDeactivateCandidacy{}, TypeTxDeactivateCandidacy, ByteTxDeactivateCandidacy)
 	sdk.TxMapper.RegisterImplementation(TxUpdateCandidacyAccount{}, TypeTxUpdateCandidacyAccount, ByteTxUpdateCandidacyAccount)
 	sdk.TxMapper.RegisterImplementation(TxAcceptCandidacyAccountUpdate{}, TypeTxAcceptCandidacyAccountUpdate, ByteTxAcceptCandidacyAccountUpdate)
+	sdk.TxMapper.RegisterImplementation(TxDelegate{}, TypeTxDelegate, ByteTxDelegate)
+	sdk.TxMapper.RegisterImplementation(TxUnbond{}, TypeTxUnbond, ByteTxUnbond)
+	sdk.TxMapper.RegisterImplementation(TxUnjail{}, TypeTxUnjail, ByteTxUnjail)
+	sdk.TxMapper.RegisterImplementation(TxCancelCandidacyAccountUpdate{}, TypeTxCancelCandidacyAccountUpdate, ByteTxCancelCandidacyAccountUpdate)
 }
 
 //Verify interface at compile time
 var _, _, _, _, _, _, _, _ sdk.TxInner = &TxDeclareCandidacy{}, &TxUpdateCandidacy{}, &TxWithdrawCandidacy{}, TxVerifyCandidacy{}, &TxActivateCandidacy{}, &TxUpdateCandidacyAccount{}, &TxAcceptCandidacyAccountUpdate{}, &TxDeactivateCandidacy{}
+var _, _, _, _ sdk.TxInner = &TxDelegate{}, &TxUnbond{}, &TxUnjail{}, &TxCancelCandidacyAccountUpdate{}
 
 type TxDeclareCandidacy struct {
 	PubKey      string      `json:"pub_key"`
@@ -177,3 +190,80 @@ func NewTxAcceptCandidacyAccountUpdate(accountUpdateRequestId int64) sdk.Tx {
 
 // Wrap - Wrap a Tx as a Travis Tx
 func (tx TxAcceptCandidacyAccountUpdate) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+type TxCancelCandidacyAccountUpdate struct {
+	AccountUpdateRequestId int64 `json:"account_update_request_id"`
+}
+
+func (tx TxCancelCandidacyAccountUpdate) ValidateBasic() error {
+	return nil
+}
+
+func NewTxCancelCandidacyAccountUpdate(accountUpdateRequestId int64) sdk.Tx {
+	return TxCancelCandidacyAccountUpdate{
+		accountUpdateRequestId,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Travis Tx
+func (tx TxCancelCandidacyAccountUpdate) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+type TxDelegate struct {
+	CandidateAddress common.Address `json:"candidate_address"`
+	Amount           string         `json:"amount"`
+}
+
+// ValidateBasic - Check for a positive amount
+func (tx TxDelegate) ValidateBasic() error {
+	if amount, ok := sdk.NewIntFromString(tx.Amount); !ok || amount.LTE(sdk.ZeroInt) {
+		return ErrBadAmount()
+	}
+	return nil
+}
+
+func NewTxDelegate(candidateAddress common.Address, amount string) sdk.Tx {
+	return TxDelegate{
+		CandidateAddress: candidateAddress,
+		Amount:           amount,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Travis Tx
+func (tx TxDelegate) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+type TxUnbond struct {
+	CandidateAddress common.Address `json:"candidate_address"`
+	Amount           string         `json:"amount"`
+}
+
+// ValidateBasic - Check for a positive amount
+func (tx TxUnbond) ValidateBasic() error {
+	if amount, ok := sdk.NewIntFromString(tx.Amount); !ok || amount.LTE(sdk.ZeroInt) {
+		return ErrBadAmount()
+	}
+	return nil
+}
+
+func NewTxUnbond(candidateAddress common.Address, amount string) sdk.Tx {
+	return TxUnbond{
+		CandidateAddress: candidateAddress,
+		Amount:           amount,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Travis Tx
+func (tx TxUnbond) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+type TxUnjail struct{}
+
+// ValidateBasic - Nothing to check, the sender is the jailed candidate
+func (tx TxUnjail) ValidateBasic() error {
+	return nil
+}
+
+func NewTxUnjail() sdk.Tx {
+	return TxUnjail{}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Travis Tx
+func (tx TxUnjail) Wrap() sdk.Tx { return sdk.Tx{tx} }
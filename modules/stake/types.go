@@ -3,6 +3,7 @@ package stake
 import (
 	"bytes"
 	"encoding/json"
+	"github.com/second-state/devchain/sdk"
 	"github.com/second-state/devchain/sdk/state"
 	"sort"
 
@@ -11,7 +12,6 @@ import (
 
 	"github.com/second-state/devchain/types"
 	"github.com/second-state/devchain/utils"
-	"github.com/tendermint/tendermint/crypto/ed25519"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -30,12 +30,15 @@ type Candidate struct {
 	PubKey                types.PubKey `json:"pub_key"`                 // Pubkey of candidate
 	OwnerAddress          string       `json:"owner_address"`           // Sender of BondTx - UnbondTx returns here
 	VotingPower           int64        `json:"voting_power"`
+	Shares                string       `json:"shares"`                  // Total amount of CMTs bonded to the candidate
 	CreatedAt             int64        `json:"created_at"`
 	Description           Description  `json:"description"`
 	Verified              string       `json:"verified"`
 	Active                string       `json:"active"`
 	BlockHeight           int64        `json:"block_height"`
 	State                 string       `json:"state"`
+	JailedUntil           int64        `json:"jailed_until"` // Block height from which a jailed candidate may unjail, 0 if not jailed
+	CooldownUntil         int64        `json:"cooldown_until"` // Block height from which a withdrawn or deactivated candidate may reactivate
 }
 
 type Description struct {
@@ -60,26 +63,33 @@ func (c *Candidate) Hash() []byte {
 	return hasher.Sum(nil)
 }
 
+// ParseShares returns the total bonded stake of the candidate
+func (c *Candidate) ParseShares() sdk.Int {
+	return utils.ParseInt(c.Shares)
+}
+
+// CalcVotingPower derives the voting power from the total bonded stake,
+// one unit of voting power for each whole CMT
 func (c *Candidate) CalcVotingPower() (res int64) {
-	return 1000
+	return c.ParseShares().Div(sdk.E18Int).Int64()
 }
 
 func (c Candidate) IsActive() bool {
 	return c.Active == "Y"
 }
 
+func (c Candidate) IsJailed() bool {
+	return c.JailedUntil > 0
+}
+
 // Validator is one of the top Candidates
 type Validator Candidate
 
 // ABCIValidator - Get the validator from a bond value
 func (v Validator) ABCIValidator() abci.Validator {
-	pk := v.PubKey.PubKey.(ed25519.PubKeyEd25519)
 	return abci.Validator{
-		PubKey: abci.PubKey{
-			Type: abci.PubKeyEd25519,
-			Data: pk[:],
-		},
-		Power: v.VotingPower,
+		PubKey: v.PubKey.ABCIPubKey(),
+		Power:  v.VotingPower,
 	}
 }
 
@@ -119,41 +129,51 @@ func (cs Candidates) updateVotingPower(updates PubKeyUpdates) Candidates {
 			}
 		}
 
-		if c.Active == "N" {
+		if c.IsJailed() {
+			c.VotingPower = 0
+			c.State = "Jailed"
+		} else if c.Active == "N" {
 			c.VotingPower = 0
 			c.State = "Candidate"
 		} else {
 			c.VotingPower = c.CalcVotingPower()
-			c.State = "Validator"
+			c.State = "Candidate"
 		}
-		updateCandidate(c)
 	}
 
 	cs.Sort()
 
+	// only the top candidates become validators, the rest are on standby
+	maxValidators := utils.GetParams().MaxValidators
+	var n uint64
+	for _, c := range cs {
+		if c.VotingPower > 0 {
+			if maxValidators == 0 || n < maxValidators {
+				c.State = "Validator"
+				n++
+			} else {
+				c.State = "Standby"
+			}
+		}
+		updateCandidate(c)
+	}
+
 	return cs
 }
 
 // Validators - get the most recent updated validator set from the
-// Candidates. These bonds are already sorted by VotingPower from
-// the UpdateVotingPower function which is the only function which
-// is to modify the VotingPower
+// Candidates. The state of the candidates is assigned by the
+// UpdateVotingPower function which is the only function which
+// is to modify the VotingPower, standby candidates are left out
 func (cs Candidates) Validators() Validators {
 	cs.Sort()
 
-	//test if empty
-	if len(cs) == 1 {
-		if cs[0].VotingPower == 0 {
-			return nil
-		}
-	}
-
-	validators := make(Validators, len(cs))
-	for i, c := range cs {
-		if c.VotingPower == 0 { //exit as soon as the first Voting power set to zero is found
-			return validators[:i]
+	var validators Validators
+	for _, c := range cs {
+		if c.State != "Validator" || c.VotingPower == 0 {
+			continue
 		}
-		validators[i] = c.Validator()
+		validators = append(validators, c.Validator())
 	}
 
 	return validators
@@ -199,8 +219,7 @@ func (vs Validators) validatorsChanged(vs2 Validators) (changed []abci.Validator
 				j++
 				continue
 			} // else, the old validator has been removed
-			pk := vs[i].PubKey.PubKey.(ed25519.PubKeyEd25519)
-			changed[n] = abci.Ed25519Validator(pk[:], 0)
+			changed[n] = abci.Validator{PubKey: vs[i].PubKey.ABCIPubKey(), Power: 0}
 			n++
 			i++
 			continue
@@ -220,8 +239,7 @@ func (vs Validators) validatorsChanged(vs2 Validators) (changed []abci.Validator
 
 	// remove any excess validators left in set 1
 	for ; i < len(vs); i, n = i+1, n+1 {
-		pk := vs[i].PubKey.PubKey.(ed25519.PubKeyEd25519)
-		changed[n] = abci.Ed25519Validator(pk[:], 0)
+		changed[n] = abci.Validator{PubKey: vs[i].PubKey.ABCIPubKey(), Power: 0}
 	}
 
 	return changed[:n]
@@ -263,6 +281,21 @@ func UpdateValidatorSet(store state.SimpleDB) (change []abci.Validator, err erro
 	return
 }
 
+// SaveCandidatesSnapshot - keep a copy of the listed candidates in the versioned
+// store whenever they change, so they can be queried as of any past height
+func SaveCandidatesSnapshot(store state.SimpleDB) {
+	candidates := getCandidatesInternal(listedCandidatesCond())
+
+	b, err := json.Marshal(candidates)
+	if err != nil {
+		panic(err)
+	}
+
+	if !bytes.Equal(store.Get(utils.CandidatesKey), b) {
+		store.Set(utils.CandidatesKey, b)
+	}
+}
+
 // Deactivate the validators
 func (vs Validators) Deactivate() {
 	// update voting power
@@ -301,6 +334,81 @@ func (c *CandidateAccountUpdateRequest) Hash() []byte {
 	return hasher.Sum(nil)
 }
 
+// isExpired - a pending request can only be accepted within the expire period
+func (c *CandidateAccountUpdateRequest) isExpired(height int64) bool {
+	period := utils.GetParams().AccountUpdateRequestExpirePeriod
+	return period > 0 && height >= c.CreatedBlockHeight+int64(period)
+}
+
+// ExpireCandidateAccountUpdateRequests - mark the pending requests which
+// have not been accepted within the expire period as expired
+func ExpireCandidateAccountUpdateRequests(height int64) {
+	for _, req := range getPendingCandidateAccountUpdateRequests() {
+		if req.isExpired(height) {
+			req.State = "EXPIRED"
+			updateCandidateAccountUpdateRequest(req)
+		}
+	}
+}
+
+// Delegation records the shares a delegator has bonded to a candidate
+type Delegation struct {
+	Id               int64          `json:"id"`
+	DelegatorAddress common.Address `json:"delegator_address"`
+	CandidateId      int64          `json:"candidate_id"`
+	Shares           string         `json:"shares"`
+	BlockHeight      int64          `json:"block_height"`
+}
+
+func (d *Delegation) Hash() []byte {
+	excludedFields := []string{"Id"}
+	bs := types.Hash(d, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+// ParseShares returns the amount of CMTs bonded by the delegator
+func (d *Delegation) ParseShares() sdk.Int {
+	return utils.ParseInt(d.Shares)
+}
+
+// Unbonding is the pending release of coins unbonded from a candidate,
+// they stay locked until the unbonding period ends
+type Unbonding struct {
+	Id                 int64          `json:"id"`
+	CandidateId        int64          `json:"candidate_id"`
+	DelegatorAddress   common.Address `json:"delegator_address"`
+	Amount             string         `json:"amount"`
+	BlockHeight        int64          `json:"block_height"`
+	ReleaseBlockHeight int64          `json:"release_block_height"`
+}
+
+func (u *Unbonding) Hash() []byte {
+	excludedFields := []string{"Id"}
+	bs := types.Hash(u, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
+// CandidateVerification is the approval of a verifier committee member
+// to mark a candidate as verified or unverified
+type CandidateVerification struct {
+	CandidateId int64          `json:"candidate_id"`
+	Verifier    common.Address `json:"verifier"`
+	Verified    string         `json:"verified"`
+	BlockHeight int64          `json:"block_height"`
+}
+
+func (v *CandidateVerification) Hash() []byte {
+	var excludedFields []string
+	bs := types.Hash(v, excludedFields)
+	hasher := ripemd160.New()
+	hasher.Write(bs)
+	return hasher.Sum(nil)
+}
+
 type PubKeyUpdate struct {
 	OldPubKey   types.PubKey `json:"old_pub_key"`
 	NewPubKey   types.PubKey `json:"new_pub_key"`
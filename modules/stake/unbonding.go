@@ -0,0 +1,19 @@
+package stake
+
+import (
+	"github.com/second-state/devchain/commons"
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/utils"
+)
+
+// ReleaseUnbondings - return the unbonded coins whose unbonding period
+// ends at the given height to their delegators
+func ReleaseUnbondings(height int64) {
+	for _, unbonding := range getDueUnbondings(height) {
+		amount := utils.ParseInt(unbonding.Amount)
+		if amount.GT(sdk.ZeroInt) {
+			commons.Transfer(utils.HoldAccount, unbonding.DelegatorAddress, amount)
+		}
+		removeUnbonding(unbonding)
+	}
+}
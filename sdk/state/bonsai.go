@@ -62,6 +62,10 @@ func (b *Bonsai) GetVersionedWithProof(key []byte, version int64) ([]byte, *iavl
 	return b.Tree.GetVersionedWithProof(key, version)
 }
 
+func (b *Bonsai) GetVersionedRangeWithProof(start, end []byte, limit int, version int64) ([][]byte, [][]byte, *iavl.RangeProof, error) {
+	return b.Tree.GetVersionedRangeWithProof(start, end, limit, version)
+}
+
 func (b *Bonsai) List(start, end []byte, limit int) []Model {
 	res := []Model{}
 	stopAtCount := func(key []byte, value []byte) (stop bool) {
@@ -58,6 +58,18 @@ func (s State) LatestHeight() int64 {
 	return h
 }
 
+// Rollback loads the state committed at the version, discarding the working states.
+// The versions after it are overwritten by the next commits.
+func (s *State) Rollback(version int64) error {
+	if _, err := s.committed.Tree.LoadVersion(version); err != nil {
+		return err
+	}
+
+	s.deliverTx = s.committed.Checkpoint()
+	s.checkTx = s.committed.Checkpoint()
+	return nil
+}
+
 // LatestHash is the root hash of the last state we have
 // committed
 func (s State) LatestHash() []byte {
@@ -21,6 +21,7 @@ import (
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/p2p"
 	pv "github.com/tendermint/tendermint/privval"
+	tmtypes "github.com/tendermint/tendermint/types"
 	"os/exec"
 )
 
@@ -85,9 +86,15 @@ func initTendermint() {
 	if cmn.FileExists(genFile) {
 		logger.Info("Found genesis file", "path", genFile)
 	} else {
+		// validators may use secp256k1 keys besides the default ed25519 ones
+		consensusParams := tmtypes.DefaultConsensusParams()
+		consensusParams.Validator.PubKeyTypes = []string{tmtypes.ABCIPubKeyTypeEd25519, tmtypes.ABCIPubKeyTypeSecp256k1}
+
 		genDoc := types.GenesisDoc{
-			ChainID: viper.GetString(FlagChainID),
-			Params:  utils.DefaultParams(),
+			ChainID:                 viper.GetString(FlagChainID),
+			ConsensusParams:         consensusParams,
+			Params:                  utils.DefaultParams(),
+			IncrementalDbHashHeight: 1,
 		}
 
 		genDoc.Validators = []types.GenesisValidator{{
@@ -171,13 +178,25 @@ func initDevChainDb() {
 		defer db.Close()
 
 		sqlStmt := `
-	create table candidates(id integer not null primary key autoincrement, address text not null, pub_key text not null, voting_power integer default 0, name text not null default '', website text not null default '', location text not null default '', email text not null default '', profile text not null default '', verified text not null default 'N', active text not null default 'Y', state text not null default '', hash text not null default '', block_height integer not null, created_at integer not null);
+	create table candidates(id integer not null primary key autoincrement, address text not null, pub_key text not null, voting_power integer default 0, shares text not null default '0', name text not null default '', website text not null default '', location text not null default '', email text not null default '', profile text not null default '', verified text not null default 'N', active text not null default 'Y', state text not null default '', jailed_until integer not null default 0, cooldown_until integer not null default 0, hash text not null default '', block_height integer not null, created_at integer not null);
 	create unique index idx_candidates_pub_key on candidates(pub_key);
 	create unique index idx_candidates_address on candidates(address);
 	create index idx_candidates_hash on candidates(hash);
 	create table candidate_account_update_requests(id integer primary key autoincrement, candidate_id integer not null, from_address text not null, to_address text not null, created_block_height integer not null, accepted_block_height integer not null, state text not null, hash text not null default '');
 	create index idx_candidate_account_update_requests_to_address on candidate_account_update_requests(to_address);
 	create index idx_candidate_account_update_requests_hash on candidate_account_update_requests(hash);
+	create table delegations(id integer primary key autoincrement, delegator_address text not null, candidate_id integer not null, shares text not null default '0', block_height integer not null, hash text not null default '', unique(delegator_address, candidate_id));
+	create index idx_delegations_candidate_id on delegations(candidate_id);
+	create index idx_delegations_hash on delegations(hash);
+	create table candidate_verifications(candidate_id integer not null, verifier text not null, verified text not null, block_height integer not null, hash text not null default '', unique(candidate_id, verifier) on conflict replace);
+	create index idx_candidate_verifications_hash on candidate_verifications(hash);
+	create table unbondings(id integer primary key autoincrement, candidate_id integer not null, delegator_address text not null, amount text not null, block_height integer not null, release_block_height integer not null, hash text not null default '');
+	create index idx_unbondings_delegator_address on unbondings(delegator_address);
+	create index idx_unbondings_release_block_height on unbondings(release_block_height);
+	create index idx_unbondings_hash on unbondings(hash);
+	create table fee_distributions(id integer primary key autoincrement, block_height integer not null, address text not null, amount text not null, type text not null, hash text not null default '');
+	create index idx_fee_distributions_block_height on fee_distributions(block_height);
+	create index idx_fee_distributions_hash on fee_distributions(hash);
 
  	create table governance_proposal(id text not null primary key, type text not null, proposer text not null, block_height integer not null, expire_timestamp integer not null, expire_block_height integer not null, hash text not null default '', result text not null default '', result_msg text not null default '', result_block_height integer not null default 0);
 	create index idx_governance_proposal_hash on governance_proposal(hash);
@@ -191,6 +210,22 @@ func initDevChainDb() {
 	create index idx_governance_retire_program_detail_proposal_id on governance_retire_program_detail(proposal_id);
 	create table governance_upgrade_program_detail(proposal_id text not null, retired_version text not null, name text not null, version text not null, fileurl text not null, md5 text not null, reason text not null);
 	create index idx_governance_upgrade_program_detail_proposal_id on governance_retire_program_detail(proposal_id);
+	create table governance_call_contract_detail(proposal_id text not null, contract_address text not null, value text not null, calldata text not null, gas_limit integer not null, reason text not null, status text not null, gas_used integer not null default 0, return_data text not null default '', tx_hash text not null default '', logs text not null default '');
+	create index idx_governance_call_contract_detail_proposal_id on governance_call_contract_detail(proposal_id);
+	create table governance_change_params_detail(proposal_id text not null, params text not null, reason text not null);
+	create index idx_governance_change_params_detail_proposal_id on governance_change_params_detail(proposal_id);
+	create table governance_param_change_log(proposal_id text not null, name text not null, old_value text not null, new_value text not null, block_height integer not null, hash text not null default '');
+	create index idx_governance_param_change_log_name on governance_param_change_log(name);
+	create table governance_grant_detail(proposal_id text not null, from_address text not null, to_address text not null, amount text not null, tranches integer not null, interval integer not null, reason text not null, status text not null, paid_amount text not null, paid_tranches integer not null default 0, next_block_height integer not null default 0);
+	create index idx_governance_grant_detail_proposal_id on governance_grant_detail(proposal_id);
+	create index idx_governance_grant_detail_status on governance_grant_detail(status, next_block_height);
+	create table governance_revoke_grant_detail(proposal_id text not null, grant_id text not null, reason text not null);
+	create index idx_governance_revoke_grant_detail_proposal_id on governance_revoke_grant_detail(proposal_id);
+	create table scheduled_txs(id integer primary key autoincrement, tx_hash text not null, from_address text not null, to_address text not null, value text not null, data text not null, due_timestamp integer not null, block_height integer not null, status text not null, gas_used integer not null default 0, return_data text not null default '', result_msg text not null default '', executed_block_height integer not null default 0, call_hash text not null default '', logs text not null default '', hash text not null default '');
+	create index idx_scheduled_txs_due on scheduled_txs(status, due_timestamp, block_height);
+	create index idx_scheduled_txs_tx_hash on scheduled_txs(tx_hash);
+	create table governance_proposal_deposit(proposal_id text not null primary key, depositor text not null, amount text not null, status text not null, block_height integer not null, hash text not null default '');
+	create index idx_governance_proposal_deposit_hash on governance_proposal_deposit(hash);
  	create table governance_vote(proposal_id text not null, voter text not null, block_height integer not null, answer text not null,  hash text not null default '', unique(proposal_id, voter) ON conflict replace);
 	create index idx_governance_vote_voter on governance_vote(voter);
 	create index idx_governance_vote_proposal_id on governance_vote(proposal_id);
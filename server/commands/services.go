@@ -22,6 +22,8 @@ import (
 	"github.com/second-state/devchain/vm/cmd/utils"
 	emtUtils "github.com/second-state/devchain/vm/cmd/utils"
 	"github.com/second-state/devchain/vm/ethereum"
+	minerRewardStrategies "github.com/second-state/devchain/vm/strategies/miner"
+	emtTypes "github.com/second-state/devchain/vm/types"
 )
 
 type Services struct {
@@ -48,7 +50,8 @@ func startServices(rootDir string, storeApp *app.StoreApp) (*Services, error) {
 	}
 
 	// Create the ABCI app
-	ethApp, err := app.NewEthermintApplication(backend, rpcClient, nil)
+	strategy := &emtTypes.Strategy{MinerRewardStrategy: &minerRewardStrategies.RewardInflation{}}
+	ethApp, err := app.NewEthermintApplication(backend, rpcClient, strategy)
 	if err != nil {
 		log.Warn(err.Error())
 		os.Exit(1)
@@ -30,7 +30,8 @@ import (
 )
 
 const (
-	SubFlag = "sub"
+	SubFlag                     = "sub"
+	FlagIncrementalDbHashHeight = "incremental-db-hash-height"
 )
 
 // GetStartCmd - initialize a command as the start command with tick
@@ -41,6 +42,7 @@ func GetStartCmd() *cobra.Command {
 		RunE:  startCmd(),
 	}
 	startCmd.PersistentFlags().Bool(SubFlag, false, "start devchain as sub process")
+	startCmd.PersistentFlags().Int64(FlagIncrementalDbHashHeight, 0, "Height from which a chain started without it maintains the database hash incrementally, all the nodes must switch at the same height")
 	return startCmd
 }
 
@@ -126,24 +128,35 @@ func createBaseApp(rootDir string, storeApp *app.StoreApp, ethApp *app.Ethermint
 	if err != nil {
 		return nil, err
 	}
-	// if chain_id has not been set yet, load the genesis.
-	// else, assume it's been loaded
-	if app.GetChainID() == "" {
-		// If genesis file exists, set key-value options
-		genesisFile := path.Join(rootDir, DefaultConfig().TMConfig.GenesisFile())
-		if _, err := os.Stat(genesisFile); err == nil {
-			genDoc, err := loadGenesis(genesisFile)
-			if err != nil {
-				return nil, errors.Errorf("Error in LoadGenesis: %v\n", err)
-			}
+	// If genesis file exists, set key-value options
+	genesisFile := path.Join(rootDir, DefaultConfig().TMConfig.GenesisFile())
+	if _, err := os.Stat(genesisFile); err == nil {
+		genDoc, err := loadGenesis(genesisFile)
+		if err != nil {
+			return nil, errors.Errorf("Error in LoadGenesis: %v\n", err)
+		}
+
+		// the consensus params are not kept in the store, read them on every start
+		types.SetValidatorPubKeyTypes(genDoc.ConsensusParams)
 
+		// if chain_id has not been set yet, load the genesis.
+		// else, assume it's been loaded
+		if app.GetChainID() == "" {
 			app.SetChainId(genDoc.ChainID)
+			app.SetIncrementalDbHashHeight(genDoc.IncrementalDbHashHeight)
 			utils.SetParams(genDoc.Params)
 			for _, val := range genDoc.Validators {
 				stake.SetGenesisValidator(val, app.Append())
 			}
-		} else {
-			fmt.Printf("No genesis file at %s, skipping...\n", genesisFile)
+		}
+	} else {
+		fmt.Printf("No genesis file at %s, skipping...\n", genesisFile)
+	}
+
+	// an existing chain switches to the incremental database hash at the upgrade height
+	if height := viper.GetInt64(FlagIncrementalDbHashHeight); height > 0 {
+		if err := app.UpgradeIncrementalDbHash(height); err != nil {
+			return nil, err
 		}
 	}
 
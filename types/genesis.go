@@ -22,6 +22,8 @@ type GenesisDoc struct {
 	Validators      []GenesisValidator     `json:"validators"`
 	AppHash         []byte                 `json:"app_hash"`
 	Params          *utils.Params          `json:"params"`
+	// the height from which the database hash is maintained incrementally, 0 to always rescan the tables
+	IncrementalDbHashHeight int64 `json:"incremental_db_hash_height"`
 }
 
 // GenesisValidator is an initial validator.
@@ -45,6 +47,45 @@ func (genDoc *GenesisDoc) SaveAs(file string) error {
 	return cmn.WriteFile(file, genDocBytes, 0644)
 }
 
+// genesisDoc has the fields of GenesisDoc but not its json methods
+type genesisDoc GenesisDoc
+
+// tendermint reads the consensus params from the same file with amino,
+// which expects the int64 values to be quoted
+type genesisDocJSON struct {
+	genesisDoc
+	ConsensusParams json.RawMessage `json:"consensus_params,omitempty"`
+}
+
+func (genDoc GenesisDoc) MarshalJSON() ([]byte, error) {
+	doc := genesisDocJSON{genesisDoc: genesisDoc(genDoc)}
+	if genDoc.ConsensusParams != nil {
+		b, err := Cdc.MarshalJSON(genDoc.ConsensusParams)
+		if err != nil {
+			return nil, err
+		}
+		doc.ConsensusParams = b
+	}
+	return json.Marshal(doc)
+}
+
+func (genDoc *GenesisDoc) UnmarshalJSON(b []byte) error {
+	var doc genesisDocJSON
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	*genDoc = GenesisDoc(doc.genesisDoc)
+	genDoc.ConsensusParams = nil
+	if len(doc.ConsensusParams) != 0 && string(doc.ConsensusParams) != "null" {
+		genDoc.ConsensusParams = new(types.ConsensusParams)
+		if err := Cdc.UnmarshalJSON(doc.ConsensusParams, genDoc.ConsensusParams); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ValidatorHash returns the hash of the validator set contained in the GenesisDoc
 func (genDoc *GenesisDoc) ValidatorHash() []byte {
 	vals := make([]*types.Validator, len(genDoc.Validators))
@@ -82,6 +123,10 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		}
 	}
 
+	if genDoc.IncrementalDbHashHeight < 0 {
+		return errors.Errorf("The incremental database hash height cannot be negative: %d", genDoc.IncrementalDbHashHeight)
+	}
+
 	if genDoc.GenesisTime.IsZero() {
 		genDoc.GenesisTime = time.Now()
 	}
@@ -4,7 +4,13 @@ import (
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 	"fmt"
+	"strings"
+	"encoding/base64"
 	"encoding/json"
 )
 
@@ -14,31 +20,95 @@ func init() {
 	cryptoAmino.RegisterAmino(Cdc)
 }
 
+const (
+	ed25519PubKeySize   = 32
+	secp256k1PubKeySize = 33 // compressed
+)
+
 type jsonPubKey struct {
 	Pt string `json:"type"`
 	Pv string `json:"value"`
 }
 
+// ValidatorPubKeyTypes are the abci types of the keys tendermint accepts in
+// validator updates, the default consensus params only allow ed25519
+var ValidatorPubKeyTypes = []string{tmtypes.ABCIPubKeyTypeEd25519}
+
+// SetValidatorPubKeyTypes takes the validator key types from the consensus
+// params of the genesis, the defaults are kept if there are none
+func SetValidatorPubKeyTypes(params *tmtypes.ConsensusParams) {
+	if params == nil || len(params.Validator.PubKeyTypes) == 0 {
+		ValidatorPubKeyTypes = tmtypes.DefaultConsensusParams().Validator.PubKeyTypes
+		return
+	}
+	ValidatorPubKeyTypes = params.Validator.PubKeyTypes
+}
+
+// GetPubKey parses a base64 encoded public key, the key type is detected by
+// its length. The key can also be given in its full json form, e.g.
+// {"type":"tendermint/PubKeySecp256k1","value":"..."}. Only ed25519 and
+// secp256k1 keys are supported, as PubKeyString keeps the value only.
 func GetPubKey(pubKeyStr string) (pk PubKey, err error) {
 
 	if len(pubKeyStr) == 0 {
 		err = fmt.Errorf("must use --pubkey flag")
 		return
 	}
-	jpk := jsonPubKey{
-		//"AC26791624DE60",
-		"tendermint/PubKeyEd25519",
-		pubKeyStr,
+
+	var b []byte
+	if strings.HasPrefix(strings.TrimSpace(pubKeyStr), "{") {
+		b = []byte(pubKeyStr)
+	} else {
+		var raw []byte
+		raw, err = base64.StdEncoding.DecodeString(pubKeyStr)
+		if err != nil {
+			return
+		}
+
+		jpk := jsonPubKey{Pv: pubKeyStr}
+		switch len(raw) {
+		case ed25519PubKeySize:
+			jpk.Pt = "tendermint/PubKeyEd25519"
+		case secp256k1PubKeySize:
+			jpk.Pt = "tendermint/PubKeySecp256k1"
+		default:
+			err = fmt.Errorf("unsupported public key of %d bytes", len(raw))
+			return
+		}
+		b, err = json.Marshal(jpk)
+		if err != nil {
+			return
+		}
 	}
-	b, err := json.Marshal(jpk)
 
 	var cpk crypto.PubKey
 	err = Cdc.UnmarshalJSON(b, &cpk)
+	if err != nil {
+		return
+	}
+
+	switch cpk.(type) {
+	case ed25519.PubKeyEd25519, secp256k1.PubKeySecp256k1:
+	default:
+		err = fmt.Errorf("unsupported public key type %T", cpk)
+		return
+	}
 
 	pk = PubKey{cpk}
 	return
 }
 
+// IsValidatorPubKey tells whether tendermint accepts the key for a validator
+func IsValidatorPubKey(pk PubKey) bool {
+	typ := pk.ABCIPubKey().Type
+	for _, t := range ValidatorPubKeyTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
 func PubKeyString(pk PubKey) string {
 	b, err := Cdc.MarshalJSON(pk.PubKey)
 	if err != nil {
@@ -53,6 +123,11 @@ type PubKey struct {
 	crypto.PubKey
 }
 
+// ABCIPubKey converts the key to the type and bytes used in abci validator updates
+func (pk PubKey) ABCIPubKey() abci.PubKey {
+	return tmtypes.TM2PB.PubKey(pk.PubKey)
+}
+
 func (pk *PubKey) MarshalJSON() ([]byte, error) {
 	return Cdc.MarshalJSON(pk.PubKey)
 }
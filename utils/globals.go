@@ -211,4 +211,14 @@ var (
 	MintAccount    = common.HexToAddress("0000000000000000000000000000000000000000")
 	HoldAccount    = common.HexToAddress("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF")
 	GovHoldAccount = common.HexToAddress("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF")
+
+	// the sender of the contract calls of the approved proposals, funded with the value of each call
+	GovCallerAccount = common.HexToAddress("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE")
+	// the deposits of the undecided proposals
+	DepositEscrowAccount = common.HexToAddress("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD")
+	// the unpaid amounts of the grant proposals
+	GrantEscrowAccount = common.HexToAddress("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFC")
+
+	// the precompiled contract giving contracts read access to the stake and governance state
+	SystemContractAddress = common.HexToAddress("0000000000000000000000000000000000000100")
 )
@@ -2,27 +2,70 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/second-state/devchain/sdk"
 )
 
+// Params are the global parameters changed through governance proposals. The type tag
+// is the type a new value is checked against, the optional min and max tags bound the
+// value inclusively, and the optional values tag lists the allowed values separated by |
 type Params struct {
-	ProposalExpirePeriod                   uint64 `json:"proposal_expire_period" type:"uint"`
-	DeclareCandidacyGas                    uint64 `json:"declare_candidacy_gas" type:"uint"`
-	UpdateCandidacyGas                     uint64 `json:"update_candidacy_gas" type:"uint"`
-	UpdateCandidateAccountGas              uint64 `json:"update_candidate_account_gas" type:"uint"`
-	AcceptCandidateAccountUpdateRequestGas uint64 `json:"accept_candidate_account_update_request_gas" type:"uint"`
-	TransferFundProposalGas                uint64 `json:"transfer_fund_proposal_gas" type:"uint"`
-	ChangeParamsProposalGas                uint64 `json:"change_params_proposal_gas" type:"uint"`
-	DeployLibEniProposalGas                uint64 `json:"deploy_libeni_proposal_gas" type:"uint"`
-	RetireProgramProposalGas               uint64 `json:"retire_program_proposal_gas" type:"uint"`
-	UpgradeProgramProposalGas              uint64 `json:"upgrade_program_proposal_gas" type:"uint"`
-	GasPrice                               uint64 `json:"gas_price" type:"uint"`
-	LowPriceTxGasLimit                     uint64 `json:"low_price_tx_gas_limit" type:"uint"`
-	LowPriceTxSlotsCap                     int    `json:"low_price_tx_slots_cap" type:"int"`
-	FoundationAddress                      string `json:"foundation_address" type:"string"`
+	ProposalExpirePeriod                   uint64  `json:"proposal_expire_period" type:"uint" min:"1"`
+	DeclareCandidacyGas                    uint64  `json:"declare_candidacy_gas" type:"uint"`
+	UpdateCandidacyGas                     uint64  `json:"update_candidacy_gas" type:"uint"`
+	UpdateCandidateAccountGas              uint64  `json:"update_candidate_account_gas" type:"uint"`
+	AcceptCandidateAccountUpdateRequestGas uint64  `json:"accept_candidate_account_update_request_gas" type:"uint"`
+	TransferFundProposalGas                uint64  `json:"transfer_fund_proposal_gas" type:"uint"`
+	ChangeParamsProposalGas                uint64  `json:"change_params_proposal_gas" type:"uint"`
+	DeployLibEniProposalGas                uint64  `json:"deploy_libeni_proposal_gas" type:"uint"`
+	RetireProgramProposalGas               uint64  `json:"retire_program_proposal_gas" type:"uint"`
+	UpgradeProgramProposalGas              uint64  `json:"upgrade_program_proposal_gas" type:"uint"`
+	CallContractProposalGas                uint64  `json:"call_contract_proposal_gas" type:"uint"`
+	CallContractGasLimit                   uint64  `json:"call_contract_gas_limit" type:"uint" min:"21000"`
+	ScheduledTxGasLimit                    uint64  `json:"scheduled_tx_gas_limit" type:"uint" min:"21000"`
+	ProposalDeposit                        string  `json:"proposal_deposit" type:"bigint"`
+	ProposalDepositTreasury                string  `json:"proposal_deposit_treasury" type:"address"`
+	MaxOpenProposalsPerProposer            uint64  `json:"max_open_proposals_per_proposer" type:"uint"`
+	TransferFundProposalQuorum             sdk.Rat `json:"transfer_fund_proposal_quorum" type:"rat" min:"0" max:"1"`
+	TransferFundProposalThreshold          sdk.Rat `json:"transfer_fund_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	TransferFundProposalVeto               sdk.Rat `json:"transfer_fund_proposal_veto" type:"rat" min:"0" max:"1"`
+	ChangeParamProposalQuorum              sdk.Rat `json:"change_param_proposal_quorum" type:"rat" min:"0" max:"1"`
+	ChangeParamProposalThreshold           sdk.Rat `json:"change_param_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	ChangeParamProposalVeto                sdk.Rat `json:"change_param_proposal_veto" type:"rat" min:"0" max:"1"`
+	DeployLibEniProposalQuorum             sdk.Rat `json:"deploy_libeni_proposal_quorum" type:"rat" min:"0" max:"1"`
+	DeployLibEniProposalThreshold          sdk.Rat `json:"deploy_libeni_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	DeployLibEniProposalVeto               sdk.Rat `json:"deploy_libeni_proposal_veto" type:"rat" min:"0" max:"1"`
+	RetireProgramProposalQuorum            sdk.Rat `json:"retire_program_proposal_quorum" type:"rat" min:"0" max:"1"`
+	RetireProgramProposalThreshold         sdk.Rat `json:"retire_program_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	RetireProgramProposalVeto              sdk.Rat `json:"retire_program_proposal_veto" type:"rat" min:"0" max:"1"`
+	UpgradeProgramProposalQuorum           sdk.Rat `json:"upgrade_program_proposal_quorum" type:"rat" min:"0" max:"1"`
+	UpgradeProgramProposalThreshold        sdk.Rat `json:"upgrade_program_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	UpgradeProgramProposalVeto             sdk.Rat `json:"upgrade_program_proposal_veto" type:"rat" min:"0" max:"1"`
+	CallContractProposalQuorum             sdk.Rat `json:"call_contract_proposal_quorum" type:"rat" min:"0" max:"1"`
+	CallContractProposalThreshold          sdk.Rat `json:"call_contract_proposal_threshold" type:"rat" min:"1/2" max:"1"`
+	CallContractProposalVeto               sdk.Rat `json:"call_contract_proposal_veto" type:"rat" min:"0" max:"1"`
+	GasPrice                               uint64  `json:"gas_price" type:"uint" min:"1"`
+	LowPriceTxGasLimit                     uint64  `json:"low_price_tx_gas_limit" type:"uint" min:"21000"`
+	LowPriceTxSlotsCap                     int     `json:"low_price_tx_slots_cap" type:"int" min:"0"`
+	FoundationAddress                      string  `json:"foundation_address" type:"string"`
+	SlashingWindow                         uint64  `json:"slashing_window" type:"uint" min:"1"`
+	MaxMissedBlocks                        uint64  `json:"max_missed_blocks" type:"uint"`
+	JailPeriod                             uint64  `json:"jail_period" type:"uint"`
+	DoubleSignSlashRatio                   sdk.Rat `json:"double_sign_slash_ratio" type:"rat" min:"0" max:"1"`
+	ProposerFeeRatio                       sdk.Rat `json:"proposer_fee_ratio" type:"rat" min:"0" max:"1"`
+	ValidatorsFeeRatio                     sdk.Rat `json:"validators_fee_ratio" type:"rat" min:"0" max:"1"`
+	InflationRate                          sdk.Rat `json:"inflation_rate" type:"rat" min:"0" max:"1"`
+	MaxValidators                          uint64  `json:"max_validators" type:"uint"`
+	UnbondingPeriod                        uint64  `json:"unbonding_period" type:"uint"`
+	AccountUpdateRequestExpirePeriod       uint64  `json:"account_update_request_expire_period" type:"uint"`
+	VerifierCommittee                      string  `json:"verifier_committee" type:"addresses"`
+	VerifierThreshold                      uint64  `json:"verifier_threshold" type:"uint" min:"1"`
 }
 
 func DefaultParams() *Params {
@@ -37,10 +80,46 @@ func DefaultParams() *Params {
 		RetireProgramProposalGas:               2e6,
 		UpgradeProgramProposalGas:              2e6,
 		DeployLibEniProposalGas:                2e6,
+		CallContractProposalGas:                2e6,
+		CallContractGasLimit:                   3e6,                      // default gas limit of the contract call executed by an approved proposal
+		ScheduledTxGasLimit:                    3e6,                      // gas limit of a contract call scheduled by a transaction
+		ProposalDeposit:                        "1000000000000000000000", // 1000 CMTs escrowed when creating a proposal
+		ProposalDepositTreasury:                "",                       // Receiver of the deposits of expired proposals, burned if empty
+		MaxOpenProposalsPerProposer:            3,                        // Maximum number of undecided proposals per proposer, 0 for no limit
+		TransferFundProposalQuorum:             sdk.NewRat(1, 3),         // Ratio of the total voting power which has to vote for a proposal to be decided at expiry
+		TransferFundProposalThreshold:          sdk.NewRat(1, 2),         // Ratio of the non-abstaining votes required to approve a proposal
+		TransferFundProposalVeto:               sdk.NewRat(1, 3),         // Ratio of the votes vetoing a proposal which rejects it whatever the other votes
+		ChangeParamProposalQuorum:              sdk.NewRat(1, 3),
+		ChangeParamProposalThreshold:           sdk.NewRat(1, 2),
+		ChangeParamProposalVeto:                sdk.NewRat(1, 3),
+		DeployLibEniProposalQuorum:             sdk.NewRat(1, 2),
+		DeployLibEniProposalThreshold:          sdk.NewRat(2, 3),
+		DeployLibEniProposalVeto:               sdk.NewRat(1, 3),
+		RetireProgramProposalQuorum:            sdk.NewRat(2, 3),
+		RetireProgramProposalThreshold:         sdk.NewRat(2, 3),
+		RetireProgramProposalVeto:              sdk.NewRat(1, 3),
+		UpgradeProgramProposalQuorum:           sdk.NewRat(1, 2),
+		UpgradeProgramProposalThreshold:        sdk.NewRat(2, 3),
+		UpgradeProgramProposalVeto:             sdk.NewRat(1, 3),
+		CallContractProposalQuorum:             sdk.NewRat(1, 3),
+		CallContractProposalThreshold:          sdk.NewRat(1, 2),
+		CallContractProposalVeto:               sdk.NewRat(1, 3),
 		GasPrice:                               0,
 		LowPriceTxGasLimit:                     9223372036854775807, // Maximum gas limit for low-price transaction
 		LowPriceTxSlotsCap:                     2147483647,          // Maximum number of low-price transaction slots per block
 		FoundationAddress:                      "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc",
+		SlashingWindow:                         100,                                          // Number of recent blocks in which missed signatures are counted
+		MaxMissedBlocks:                        50,                                           // Validators missing more blocks than this within the window get jailed
+		JailPeriod:                             3600 / uint64(CommitSeconds),                 // Number of blocks a jailed validator has to wait before unjailing
+		DoubleSignSlashRatio:                   sdk.NewRat(5, 100),                           // Ratio of bonded stake slashed for double-signing
+		ProposerFeeRatio:                       sdk.NewRat(1, 10),                            // Ratio of the block gas fees paid to the block proposer
+		ValidatorsFeeRatio:                     sdk.NewRat(9, 10),                            // Ratio of the block gas fees shared by the other validators
+		InflationRate:                          sdk.NewRat(8, 100),                           // Yearly inflation of the bonded stake minted as block rewards
+		MaxValidators:                          21,                                           // Maximum number of validators, the other candidates are on standby, 0 for no limit
+		UnbondingPeriod:                        7 * 24 * 3600 / uint64(CommitSeconds),        // Number of blocks the unbonded coins stay locked
+		AccountUpdateRequestExpirePeriod:       7 * 24 * 3600 / uint64(CommitSeconds),        // Number of blocks a candidate account update request can be accepted in, 0 for no expiry
+		VerifierCommittee:                      "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc", // Comma separated addresses allowed to verify candidates
+		VerifierThreshold:                      1,                                            // Number of committee members required to verify a candidate
 	}
 }
 
@@ -50,8 +129,20 @@ var (
 	AwardInfosKey       = []byte{0x02} // key for award infos
 	AbsentValidatorsKey = []byte{0x03} // key for absent validators
 	PubKeyUpdatesKey    = []byte{0x04} // key for absent validators
+	CandidatesKey       = []byte{0x05} // key for the snapshot of the active candidates
+	CandidatePrefix     = []byte{0x06} // prefix of the candidate records
+	DelegationPrefix    = []byte{0x07} // prefix of the delegation records
+	ProposalPrefix      = []byte{0x08} // prefix of the governance proposal records
+	VotePrefix          = []byte{0x09} // prefix of the governance vote records
+	StoreMigratedKey    = []byte{0x0a} // key holding the version of the records copied from the database
+	UnbondingPrefix     = []byte{0x0b} // prefix of the unbonding records
+	VerificationPrefix  = []byte{0x0c} // prefix of the candidate verification records
+	AccountUpdatePrefix = []byte{0x0d} // prefix of the candidate account update request records
+	StoreVersion        = []byte{0x02} // version of the records kept in the store, they are copied again when it changes
+	DbHashHeightKey     = []byte{0x0e} // key for the height from which the database hash is incremental
 	dirty               = false
 	params              = new(Params)
+	committedParams     = new(Params) // the params stored under ParamKey by the last commit
 )
 
 // load/save the global params
@@ -79,7 +170,15 @@ func CleanParams() (before bool) {
 }
 
 func SetParam(name, value string) bool {
-	pv := reflect.ValueOf(params).Elem()
+	if !setParamField(params, name, value) {
+		return false
+	}
+	dirty = true
+	return true
+}
+
+func setParamField(p *Params, name, value string) bool {
+	pv := reflect.ValueOf(p).Elem()
 	top := pv.Type()
 	for i := 0; i < pv.NumField(); i++ {
 		fv := pv.Field(i)
@@ -108,7 +207,6 @@ func SetParam(name, value string) bool {
 					}
 				}
 			}
-			dirty = true
 			return true
 		}
 	}
@@ -116,6 +214,185 @@ func SetParam(name, value string) bool {
 	return false
 }
 
+// VerifierCommitteeMembers returns the addresses of the verifier committee,
+// falling back to the foundation address if none is set
+func (p *Params) VerifierCommitteeMembers() (members []common.Address) {
+	seen := make(map[common.Address]bool)
+	for _, addr := range strings.Split(p.VerifierCommittee, ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			continue
+		}
+		// a member listed twice counts once towards the threshold
+		if member := common.HexToAddress(addr); !seen[member] {
+			seen[member] = true
+			members = append(members, member)
+		}
+	}
+
+	// params loaded from an older state have no committee yet
+	if len(members) == 0 {
+		members = append(members, common.HexToAddress(p.FoundationAddress))
+	}
+	return
+}
+
+// SetCommittedParams keeps a copy of the params stored under ParamKey by the last
+// commit, for the readers of the committed state
+func SetCommittedParams(b []byte) {
+	p := new(Params)
+	json.Unmarshal(b, p)
+	committedParams = p
+}
+
+// GetParamValue returns the current value of the param as a string, in the format
+// SetParam accepts
+func GetParamValue(name string) (string, bool) {
+	return paramValue(params, name)
+}
+
+// GetCommittedParamValue returns the value of the param as of the last commit
+func GetCommittedParamValue(name string) (string, bool) {
+	return paramValue(committedParams, name)
+}
+
+func paramValue(p *Params, name string) (string, bool) {
+	pv := reflect.ValueOf(p).Elem()
+	top := pv.Type()
+	for i := 0; i < pv.NumField(); i++ {
+		if top.Field(i).Tag.Get("json") == name {
+			fv := pv.Field(i)
+			if r, ok := fv.Interface().(sdk.Rat); ok {
+				if r.IsNil() {
+					return "", true
+				}
+				return r.Rat.String(), true
+			}
+			return fmt.Sprint(fv.Interface()), true
+		}
+	}
+
+	return "", false
+}
+
+// ParamChange is a new value for the param of the name
+type ParamChange struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SetParamValues sets the params all at once, none of them is set
+// if any of the names is unknown or any of the values is invalid
+func SetParamValues(changes []ParamChange) bool {
+	if !CheckParamValues(changes) {
+		return false
+	}
+
+	for _, c := range changes {
+		SetParam(c.Name, c.Value)
+	}
+	return true
+}
+
+// CheckParamValue checks the value against both the type and the bounds of the param,
+// and the params it depends on keep satisfying their constraints
+func CheckParamValue(name, value string) bool {
+	return CheckParamValues([]ParamChange{{name, value}})
+}
+
+// CheckParamValues checks each of the values as CheckParamValue does, and the
+// constraints between the params once all of them are set
+func CheckParamValues(changes []ParamChange) bool {
+	for _, c := range changes {
+		if !checkParamBounds(c.Name, c.Value) {
+			return false
+		}
+	}
+
+	p := *params
+	for _, c := range changes {
+		setParamField(&p, c.Name, c.Value)
+	}
+	return checkParamConstraints(&p)
+}
+
+// checkParamConstraints checks the params depending on each other
+func checkParamConstraints(p *Params) bool {
+	// a candidate could never be verified by fewer members than the threshold
+	return p.VerifierThreshold <= uint64(len(p.VerifierCommitteeMembers()))
+}
+
+func checkParamBounds(name, value string) bool {
+	if !CheckParamType(name, value) {
+		return false
+	}
+
+	field, ok := paramField(name)
+	if !ok {
+		return false
+	}
+
+	typ := field.Tag.Get("type")
+	if min := field.Tag.Get("min"); min != "" {
+		if c, ok := compareParamValues(typ, value, min); !ok || c < 0 {
+			return false
+		}
+	}
+	if max := field.Tag.Get("max"); max != "" {
+		if c, ok := compareParamValues(typ, value, max); !ok || c > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func paramField(name string) (reflect.StructField, bool) {
+	top := reflect.TypeOf(params).Elem()
+	for i := 0; i < top.NumField(); i++ {
+		if top.Field(i).Tag.Get("json") == name {
+			return top.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// compareParamValues compares two values of the param type, it fails if either of them
+// can not be parsed or the type, such as string, has no order
+func compareParamValues(typ, a, b string) (int, bool) {
+	switch typ {
+	case "int":
+		x, err1 := strconv.ParseInt(a, 10, 64)
+		y, err2 := strconv.ParseInt(b, 10, 64)
+		if err1 == nil && err2 == nil {
+			return new(big.Int).SetInt64(x).Cmp(new(big.Int).SetInt64(y)), true
+		}
+	case "uint":
+		x, err1 := strconv.ParseUint(a, 10, 64)
+		y, err2 := strconv.ParseUint(b, 10, 64)
+		if err1 == nil && err2 == nil {
+			return new(big.Int).SetUint64(x).Cmp(new(big.Int).SetUint64(y)), true
+		}
+	case "float":
+		x, err1 := strconv.ParseFloat(a, 64)
+		y, err2 := strconv.ParseFloat(b, 64)
+		if err1 == nil && err2 == nil {
+			return big.NewFloat(x).Cmp(big.NewFloat(y)), true
+		}
+	case "bigint":
+		x, ok1 := new(big.Int).SetString(a, 10)
+		y, ok2 := new(big.Int).SetString(b, 10)
+		if ok1 && ok2 {
+			return x.Cmp(y), true
+		}
+	case "rat":
+		x, ok1 := new(big.Rat).SetString(a)
+		y, ok2 := new(big.Rat).SetString(b)
+		if ok1 && ok2 {
+			return x.Cmp(y), true
+		}
+	}
+	return 0, false
+}
+
 func CheckParamType(name, value string) bool {
 	pv := reflect.ValueOf(params).Elem()
 	top := pv.Type()
@@ -151,6 +428,20 @@ func CheckParamType(name, value string) bool {
 				}
 			case "string":
 				return true
+			case "bigint":
+				if iv, ok := new(big.Int).SetString(value, 10); ok && iv.Sign() >= 0 {
+					return true
+				}
+			case "address":
+				// empty for none
+				return value == "" || common.IsHexAddress(value)
+			case "addresses":
+				for _, addr := range strings.Split(value, ",") {
+					if !common.IsHexAddress(strings.TrimSpace(addr)) {
+						return false
+					}
+				}
+				return true
 			case "rat":
 				v := sdk.NewRat(0, 1)
 				if err := json.Unmarshal([]byte("\""+value+"\""), &v); err == nil {
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckParamValueBounds(t *testing.T) {
+	assert := assert.New(t)
+	SetParams(DefaultParams())
+
+	cases := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		// uint with a min
+		{"gas_price", "0", false},
+		{"gas_price", "1", true},
+		{"gas_price", "-1", false},
+		{"call_contract_gas_limit", "20999", false},
+		{"call_contract_gas_limit", "21000", true},
+
+		// int with a min
+		{"low_price_tx_slots_cap", "-1", false},
+		{"low_price_tx_slots_cap", "0", true},
+
+		// rat with a min and a max
+		{"inflation_rate", "0", true},
+		{"inflation_rate", "1", true},
+		{"inflation_rate", "8/100", true},
+		{"inflation_rate", "0.08", true},
+		{"inflation_rate", "101/100", false},
+		{"inflation_rate", "-1/100", false},
+		{"inflation_rate", "abc", false},
+		{"transfer_fund_proposal_threshold", "1/2", true},
+		{"transfer_fund_proposal_threshold", "49/100", false},
+		{"transfer_fund_proposal_threshold", "2/3", true},
+		{"transfer_fund_proposal_threshold", "3/2", false},
+
+		// unbounded
+		{"proposal_deposit", "0", true},
+		{"proposal_deposit", "-1", false},
+		{"max_validators", "0", true},
+
+		// formats
+		{"proposal_deposit_treasury", "", true},
+		{"proposal_deposit_treasury", "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc", true},
+		{"proposal_deposit_treasury", "treasury", false},
+
+		{"unknown_param", "1", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.valid, CheckParamValue(c.name, c.value), "%s = %s", c.name, c.value)
+	}
+}
+
+func TestCompareParamValues(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		typ  string
+		a, b string
+		cmp  int
+		ok   bool
+	}{
+		{"uint", "1", "2", -1, true},
+		{"uint", "2", "2", 0, true},
+		{"uint", "x", "2", 0, false},
+		{"int", "-1", "0", -1, true},
+		{"int", "1", "y", 0, false},
+		{"bigint", "100000000000000000000", "1", 1, true},
+		{"bigint", "1", "1e3", 0, false},
+		{"rat", "1/2", "0.5", 0, true},
+		{"rat", "2/3", "1/2", 1, true},
+		{"rat", "1/2", "half", 0, false},
+		{"string", "a", "b", 0, false},
+	}
+
+	for _, c := range cases {
+		cmp, ok := compareParamValues(c.typ, c.a, c.b)
+		assert.Equal(c.ok, ok, "%s %s %s", c.typ, c.a, c.b)
+		assert.Equal(c.cmp, cmp, "%s %s %s", c.typ, c.a, c.b)
+	}
+}
+
+func TestCheckParamValuesVerifierThreshold(t *testing.T) {
+	assert := assert.New(t)
+	SetParams(DefaultParams())
+
+	committee := "0x7eff122b94897ea5b0e2a9abf47b86337fafebdc,0x77beb894fc9b0ed41231e51f128a347043960a9d"
+	assert.False(CheckParamValue("verifier_threshold", "2"))
+	assert.True(CheckParamValues([]ParamChange{{"verifier_committee", committee}, {"verifier_threshold", "2"}}))
+	assert.False(CheckParamValues([]ParamChange{{"verifier_committee", committee}, {"verifier_threshold", "3"}}))
+}
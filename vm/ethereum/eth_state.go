@@ -2,15 +2,18 @@ package ethereum
 
 import (
 	"bytes"
+	"encoding/json"
 	"math/big"
+	"strconv"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
@@ -20,6 +23,7 @@ import (
 	"github.com/second-state/devchain/commons"
 	"github.com/second-state/devchain/errors"
 	gov "github.com/second-state/devchain/modules/governance"
+	"github.com/second-state/devchain/modules/schedule"
 	"github.com/second-state/devchain/sdk"
 	"github.com/second-state/devchain/utils"
 	emtTypes "github.com/second-state/devchain/vm/types"
@@ -71,12 +75,20 @@ func (es *EthState) DeliverTx(tx *ethTypes.Transaction) abciTypes.ResponseDelive
 	return es.work.deliverTx(blockchain, es.ethConfig, chainConfig, blockHash, tx)
 }
 
+// Execute the calls scheduled by earlier transactions which are due at the beginning of the block.
+func (es *EthState) ExecuteScheduledTxs() {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	es.work.executeScheduledTxs(es.ethereum.BlockChain())
+}
+
 // Accumulate validator rewards.
-func (es *EthState) AccumulateRewards(config *params.ChainConfig, strategy *emtTypes.Strategy) {
+func (es *EthState) AccumulateRewards(config *params.ChainConfig, strategy *emtTypes.Strategy, validators []emtTypes.RewardedValidator) []emtTypes.AwardInfo {
 	es.mtx.Lock()
 	defer es.mtx.Unlock()
 
-	es.work.accumulateRewards(config, strategy)
+	return es.work.accumulateRewards(config, strategy, validators)
 }
 
 // Commit and reset the work.
@@ -184,10 +196,12 @@ type workState struct {
 }
 
 // nolint: unparam
-func (ws *workState) accumulateRewards(config *params.ChainConfig, strategy *emtTypes.Strategy) {
-
-	ethash.AccumulateRewards(config, ws.state, ws.header, []*ethTypes.Header{})
+func (ws *workState) accumulateRewards(config *params.ChainConfig, strategy *emtTypes.Strategy, validators []emtTypes.RewardedValidator) (awards []emtTypes.AwardInfo) {
+	if strategy != nil && strategy.MinerRewardStrategy != nil {
+		awards = strategy.AccumulateRewards(ws.state, ws.header, validators)
+	}
 	ws.header.GasUsed = *ws.totalUsedGas
+	return
 }
 
 // Runs ApplyTransaction against the ethereum blockchain, fetches any logs,
@@ -200,6 +214,8 @@ func (ws *workState) deliverTx(blockchain *core.BlockChain, config *eth.Config,
 	ws.travisTxIndex = len(utils.StateChangeQueue)
 
 	ws.state.Prepare(tx.Hash(), blockHash, ws.txIndex)
+	schedule.SetDeliveringTx(tx.Hash())
+	defer schedule.ResetDeliveringTx()
 	receipt, usedGas, err := core.ApplyTransaction(
 		chainConfig,
 		blockchain,
@@ -214,6 +230,9 @@ func (ws *workState) deliverTx(blockchain *core.BlockChain, config *eth.Config,
 	if err != nil {
 		return abciTypes.ResponseDeliverTx{Code: errors.CodeTypeInternalErr, Log: err.Error()}
 	}
+	if receipt.Status == ethTypes.ReceiptStatusSuccessful {
+		schedule.SaveScheduledTxs()
+	}
 
 	usedGasFee := big.NewInt(0).Mul(new(big.Int).SetUint64(usedGas), tx.GasPrice())
 	ws.totalUsedGasFee.Add(ws.totalUsedGasFee, usedGasFee)
@@ -239,11 +258,12 @@ func (ws *workState) commit(blockchain *core.BlockChain, db ethdb.Database, rece
 	proposalIds := utils.PendingProposal.ReachMin(int64(ws.parent.Time()), currentHeight)
 	for _, pid := range proposalIds {
 		proposal := gov.GetProposalById(pid)
+		checkResult := gov.CheckProposal(pid, nil)
 
 		switch proposal.Type {
 		case gov.TRANSFER_FUND_PROPOSAL:
 			amount, _ := sdk.NewIntFromString(proposal.Detail["amount"].(string))
-			switch gov.CheckProposal(pid, nil) {
+			switch checkResult {
 			case "approved":
 				commons.TransferWithReactor(utils.GovHoldAccount, *proposal.Detail["to"].(*common.Address), amount, gov.ProposalReactor{proposal.Id, currentHeight, "Approved"})
 			case "rejected":
@@ -251,11 +271,30 @@ func (ws *workState) commit(blockchain *core.BlockChain, db ethdb.Database, rece
 			default:
 				commons.TransferWithReactor(utils.GovHoldAccount, *proposal.Detail["from"].(*common.Address), amount, gov.ProposalReactor{proposal.Id, currentHeight, "Expired"})
 			}
-		case gov.CHANGE_PARAM_PROPOSAL:
-			switch gov.CheckProposal(pid, nil) {
+		case gov.GRANT_PROPOSAL:
+			amount, _ := sdk.NewIntFromString(proposal.Detail["amount"].(string))
+			switch checkResult {
 			case "approved":
-				utils.SetParam(proposal.Detail["name"].(string), proposal.Detail["value"].(string))
+				gov.StartGrant(proposal, currentHeight)
 				gov.ProposalReactor{proposal.Id, currentHeight, "Approved"}.React("success", "")
+			case "rejected":
+				commons.TransferWithReactor(utils.GrantEscrowAccount, *proposal.Detail["from"].(*common.Address), amount, gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"})
+			default:
+				commons.TransferWithReactor(utils.GrantEscrowAccount, *proposal.Detail["from"].(*common.Address), amount, gov.ProposalReactor{proposal.Id, currentHeight, "Expired"})
+			}
+		case gov.REVOKE_GRANT_PROPOSAL:
+			switch checkResult {
+			case "approved":
+				gov.ProposalReactor{proposal.Id, currentHeight, "Approved"}.React("success", gov.RevokeGrant(proposal.Detail["grant_id"].(string)))
+			case "rejected":
+				gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"}.React("success", "")
+			default:
+				gov.ProposalReactor{proposal.Id, currentHeight, "Expired"}.React("success", "")
+			}
+		case gov.CHANGE_PARAM_PROPOSAL, gov.CHANGE_PARAMS_PROPOSAL:
+			switch checkResult {
+			case "approved":
+				gov.ProposalReactor{proposal.Id, currentHeight, "Approved"}.React("success", gov.ApplyParamChanges(proposal, currentHeight))
 			case "rejected":
 				gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"}.React("success", "")
 			default:
@@ -270,7 +309,7 @@ func (ws *workState) commit(blockchain *core.BlockChain, db ethdb.Database, rece
 					gov.UpdateDeployLibEniStatus(proposal.Id, "deployed")
 				}
 			} else {
-				switch gov.CheckProposal(pid, nil) {
+				switch checkResult {
 				case "approved":
 					if proposal.Detail["status"] != "ready" {
 						gov.CancelDownload(proposal, true)
@@ -296,7 +335,7 @@ func (ws *workState) commit(blockchain *core.BlockChain, db ethdb.Database, rece
 				// process will be killed at next block
 				utils.RetiringProposalId = pid
 			} else {
-				switch gov.CheckProposal(pid, nil) {
+				switch checkResult {
 				case "approved":
 					// process will be killed at next block
 					utils.RetiringProposalId = pid
@@ -312,7 +351,7 @@ func (ws *workState) commit(blockchain *core.BlockChain, db ethdb.Database, rece
 				// Upgrade program command to new version
 				gov.UpgradeProgramCmd(proposal)
 			} else {
-				switch gov.CheckProposal(pid, nil) {
+				switch checkResult {
 				case "approved":
 					// Upgrade program command to new version
 					gov.UpgradeProgramCmd(proposal)
@@ -323,11 +362,34 @@ func (ws *workState) commit(blockchain *core.BlockChain, db ethdb.Database, rece
 					gov.ProposalReactor{proposal.Id, currentHeight, "Expired"}.React("success", "")
 				}
 			}
+		case gov.CALL_CONTRACT_PROPOSAL:
+			amount, _ := sdk.NewIntFromString(proposal.Detail["value"].(string))
+			if proposal.Result == "Approved" {
+				ws.executeCallContractProposal(blockchain, proposal, currentHeight)
+			} else {
+				switch checkResult {
+				case "approved":
+					gov.UpdateProposalResult(proposal.Id, "Approved", "", currentHeight)
+					ws.executeCallContractProposal(blockchain, proposal, currentHeight)
+				case "rejected":
+					commons.TransferWithReactor(utils.GovHoldAccount, *proposal.Proposer, amount, gov.ProposalReactor{proposal.Id, currentHeight, "Rejected"})
+				default:
+					commons.TransferWithReactor(utils.GovHoldAccount, *proposal.Proposer, amount, gov.ProposalReactor{proposal.Id, currentHeight, "Expired"})
+				}
+			}
+		}
+
+		// the proposals decided by votes have already settled their deposits
+		if proposal.Result == "" {
+			gov.SettleDeposit(pid, checkResult, currentHeight)
 		}
 
 		utils.PendingProposal.Del(pid)
 	}
 
+	// pay the tranches of the approved grants due at this height
+	gov.PayDueGrants(currentHeight)
+
 	ws.handleStateChangeQueue()
 
 	// Commit ethereum state and update the header.
@@ -380,6 +442,117 @@ func (ws *workState) commit(blockchain *core.BlockChain, db ethdb.Database, rece
 	return blockHash, err
 }
 
+// systemCallHash is the hash the logs of a call made by the chain itself are recorded
+// under, derived from the kind and the id of the record the call comes from
+func systemCallHash(kind, id string) common.Hash {
+	return crypto.Keccak256Hash([]byte(kind), []byte(id))
+}
+
+// Execute the contract call of an approved proposal from the governance caller account,
+// which is funded with the value of the call escrowed in the governance hold account.
+// The gas of the call is taken from the block, the call fails if the block has not
+// enough gas left. The outcome is recorded in the proposal.
+func (ws *workState) executeCallContractProposal(blockchain *core.BlockChain, proposal *gov.Proposal, currentHeight int64) {
+	contractAddr := proposal.Detail["contract_address"].(*common.Address)
+	value, _ := new(big.Int).SetString(proposal.Detail["value"].(string), 10)
+	data, _ := gov.DecodeCallData(proposal.Detail["data"].(string))
+	gasLimit := proposal.Detail["gas_limit"].(uint64)
+
+	// the coins the caller already holds are left alone, only what is left of the value is refunded
+	from := utils.GovCallerAccount
+	held := new(big.Int).Set(ws.state.GetBalance(from))
+	ws.state.SubBalance(utils.GovHoldAccount, value)
+	ws.state.AddBalance(from, value)
+
+	hash := systemCallHash(gov.CALL_CONTRACT_PROPOSAL, proposal.Id)
+	ws.state.Prepare(hash, common.Hash{}, ws.txIndex)
+
+	msg := ethTypes.NewMessage(from, contractAddr, ws.state.GetNonce(from), value, gasLimit, big.NewInt(0), data, false)
+	chainConfig := ws.es.ethereum.APIBackend.ChainConfig()
+	context := core.NewEVMContext(msg, ws.header, blockchain, nil)
+	evm := vm.NewEVM(context, ws.state, chainConfig, *blockchain.GetVMConfig())
+
+	ret, gasUsed, failed, err := core.ApplyMessage(evm, msg, ws.gp)
+
+	status, resultMsg := "success", ""
+	if err != nil || failed {
+		status = "failed"
+		if err != nil {
+			resultMsg = err.Error()
+		} else {
+			resultMsg = "contract call reverted"
+		}
+	}
+	if err == nil {
+		// the rewards have been accumulated already, the header is updated here
+		*ws.totalUsedGas += gasUsed
+		ws.header.GasUsed = *ws.totalUsedGas
+	}
+	// the value is left to the caller if the call failed, it is refunded along with
+	// any coins the contract sent back
+	if left := new(big.Int).Sub(ws.state.GetBalance(from), held); left.Sign() > 0 {
+		ws.state.SubBalance(from, left)
+		ws.state.AddBalance(*proposal.Proposer, left)
+	}
+	ws.state.Finalise(true)
+
+	logs, _ := json.Marshal(ws.state.GetLogs(hash))
+	gov.UpdateCallContractResult(proposal.Id, status, gasUsed, common.Bytes2Hex(ret), hash.Hex(), string(logs))
+	gov.ProposalReactor{proposal.Id, currentHeight, "Approved"}.React("success", resultMsg)
+}
+
+// Execute the due scheduled calls in the order they are due. The sender pays the gas at
+// the current gas price, the gas is accounted in the block and the outcome is recorded
+// in the scheduled call. The nonce of the sender is left as is, the call is not one of
+// its transactions. The calls left once the block gas limit is reached are executed
+// in the next block.
+func (ws *workState) executeScheduledTxs(blockchain *core.BlockChain) {
+	currentHeight := ws.header.Number.Int64()
+	chainConfig := ws.es.ethereum.APIBackend.ChainConfig()
+	gasLimit := utils.GetParams().ScheduledTxGasLimit
+	gasPrice := new(big.Int).SetUint64(utils.GetParams().GasPrice)
+
+	for _, stx := range schedule.GetDueTxs() {
+		if ws.gp.Gas() < gasLimit {
+			break
+		}
+
+		value, _ := new(big.Int).SetString(stx.Value, 10)
+		to := stx.To
+
+		// keep the logs of the call apart from the ones of the block's transactions
+		hash := systemCallHash("scheduled_tx", strconv.FormatInt(stx.Id, 10))
+		ws.state.Prepare(hash, common.Hash{}, ws.txIndex)
+
+		nonce := ws.state.GetNonce(stx.From)
+		msg := ethTypes.NewMessage(stx.From, &to, nonce, value, gasLimit, gasPrice, common.Hex2Bytes(stx.Data), false)
+		context := core.NewEVMContext(msg, ws.header, blockchain, nil)
+		evm := vm.NewEVM(context, ws.state, chainConfig, *blockchain.GetVMConfig())
+
+		ret, gasUsed, failed, err := core.ApplyMessage(evm, msg, ws.gp)
+		ws.state.SetNonce(stx.From, nonce)
+		ws.state.Finalise(true)
+
+		stx.Status, stx.ResultMsg = "executed", ""
+		if err != nil {
+			stx.Status, stx.ResultMsg = "failed", err.Error()
+		} else {
+			if failed {
+				stx.Status, stx.ResultMsg = "failed", "contract call reverted"
+			}
+			*ws.totalUsedGas += gasUsed
+			usedGasFee := big.NewInt(0).Mul(new(big.Int).SetUint64(gasUsed), gasPrice)
+			ws.totalUsedGasFee.Add(ws.totalUsedGasFee, usedGasFee)
+		}
+		stx.GasUsed = gasUsed
+		stx.ReturnData = common.Bytes2Hex(ret)
+		stx.ExecutedBlockHeight = currentHeight
+		logs, _ := json.Marshal(ws.state.GetLogs(hash))
+		stx.CallHash, stx.Logs = hash, string(logs)
+		schedule.UpdateScheduledTxResult(stx)
+	}
+}
+
 func (ws *workState) handleStateChangeQueue() {
 	// Iterate to add/sub balance from state
 	// ws.travisTxIndex used for recording handled index of queue
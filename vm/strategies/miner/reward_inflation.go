@@ -0,0 +1,87 @@
+package minerRewardStrategies
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/second-state/devchain/sdk"
+	"github.com/second-state/devchain/utils"
+	emtTypes "github.com/second-state/devchain/vm/types"
+)
+
+// RewardInflation mints new CMTs at the yearly inflation rate set by governance,
+// based on the stake bonded to the active validators. The reward of a validator
+// is proportional to its voting power and shared by its delegators pro rata to
+// their shares, the part of the stake not held by delegations goes to the owner.
+type RewardInflation struct {
+}
+
+// Receiver returns which address should receive the gas fees.
+// They are collected in the hold account and distributed at the end of the block.
+func (r *RewardInflation) Receiver() common.Address {
+	return utils.HoldAccount
+}
+
+// AccumulateRewards mints the block rewards to the delegators of the active validators
+func (r *RewardInflation) AccumulateRewards(state *state.StateDB, header *ethTypes.Header, validators []emtTypes.RewardedValidator) (awards []emtTypes.AwardInfo) {
+	rate := utils.GetParams().InflationRate
+	if rate.IsNil() {
+		return
+	}
+
+	var totalPower int64
+	totalShares := sdk.ZeroInt
+	for _, v := range validators {
+		totalPower += v.VotingPower
+		totalShares = totalShares.Add(sdk.NewIntFromBigInt(v.Shares))
+	}
+	if totalPower == 0 {
+		return
+	}
+
+	blocksPerYear := sdk.NewInt(365 * 24 * 3600 / int64(utils.CommitSeconds))
+	total := totalShares.MulRat(rate).Div(blocksPerYear)
+	if total.LTE(sdk.ZeroInt) {
+		return
+	}
+
+	// the awards of an account bonded to several validators are summed up
+	amounts := make(map[common.Address]sdk.Int)
+	var accounts []common.Address
+	award := func(addr common.Address, amount sdk.Int) {
+		if amount.LTE(sdk.ZeroInt) {
+			return
+		}
+		if a, ok := amounts[addr]; ok {
+			amounts[addr] = a.Add(amount)
+		} else {
+			amounts[addr] = amount
+			accounts = append(accounts, addr)
+		}
+	}
+
+	for _, v := range validators {
+		reward := total.Mul(sdk.NewInt(v.VotingPower)).Div(sdk.NewInt(totalPower))
+		shares := sdk.NewIntFromBigInt(v.Shares)
+		if reward.LTE(sdk.ZeroInt) || shares.LTE(sdk.ZeroInt) {
+			award(v.Owner, reward)
+			continue
+		}
+
+		remaining := reward
+		for _, d := range v.Delegations {
+			amount := reward.Mul(sdk.NewIntFromBigInt(d.Shares)).Div(shares)
+			award(d.Delegator, amount)
+			remaining = remaining.Sub(amount)
+		}
+		award(v.Owner, remaining)
+	}
+
+	for _, addr := range accounts {
+		state.AddBalance(addr, amounts[addr].Int)
+		awards = append(awards, emtTypes.AwardInfo{Address: addr, Amount: amounts[addr].String()})
+	}
+
+	return
+}
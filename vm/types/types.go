@@ -1,7 +1,10 @@
 package types
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/tendermint/tendermint/abci/types"
@@ -10,6 +13,29 @@ import (
 // MinerRewardStrategy is a mining strategy
 type MinerRewardStrategy interface {
 	Receiver() common.Address
+	// AccumulateRewards credits the block rewards of the validators to the state
+	// and returns the amount awarded to each account
+	AccumulateRewards(state *state.StateDB, header *ethTypes.Header, validators []RewardedValidator) []AwardInfo
+}
+
+// RewardedValidator is an active validator with the stake bonded to it
+type RewardedValidator struct {
+	Owner       common.Address
+	VotingPower int64
+	Shares      *big.Int // the total stake, including the part not held by delegations
+	Delegations []Delegation
+}
+
+// Delegation is the stake bonded to a validator by a delegator
+type Delegation struct {
+	Delegator common.Address
+	Shares    *big.Int
+}
+
+// AwardInfo is the block reward credited to an account
+type AwardInfo struct {
+	Address common.Address `json:"address"`
+	Amount  string         `json:"amount"`
 }
 
 // ValidatorsStrategy is a validator strategy